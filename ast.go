@@ -0,0 +1,325 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Node is a parsed clue. Nodes compile to the predicate functions in
+// predicates.go and print back to canonical English, so that parsing the
+// printed form of a node yields an equal node. Nodes that consult the
+// dictionary read it from the Context they are compiled with.
+type Node interface {
+	fmt.Stringer
+	Compile(ctx *Context) func(word string) bool
+}
+
+// noMaxLength marks a LengthRange without an upper bound.
+const noMaxLength = -1
+
+// StartsWith matches words beginning with Prefix.
+type StartsWith struct {
+	Prefix string
+}
+
+// EndsWith matches words ending with Suffix.
+type EndsWith struct {
+	Suffix string
+}
+
+// StartsAndEndsWith matches words both beginning and ending with Affix.
+type StartsAndEndsWith struct {
+	Affix string
+}
+
+// Contains matches words containing Substring anywhere.
+type Contains struct {
+	Substring string
+}
+
+// LengthRange matches words with between Min and Max letters inclusive.
+// Max is noMaxLength if there is no upper bound.
+type LengthRange struct {
+	Min int
+	Max int
+}
+
+// CountAtLeast matches words containing at least N occurrences of Substring.
+type CountAtLeast struct {
+	Substring string
+	N         int
+}
+
+// DoubleLetter matches words with two identical letters in a row.
+type DoubleLetter struct{}
+
+// Always matches every word.
+type Always struct{}
+
+// Not matches words that Node does not match.
+type Not struct {
+	Node Node
+}
+
+// And matches words that all Nodes match.
+type And struct {
+	Nodes []Node
+}
+
+// Or matches words that any of Nodes match.
+type Or struct {
+	Nodes []Node
+}
+
+func (n StartsWith) Compile(ctx *Context) func(word string) bool {
+	return wordStartsWith(n.Prefix)
+}
+
+func (n StartsWith) String() string {
+	return "Starts with " + n.Prefix
+}
+
+func (n EndsWith) Compile(ctx *Context) func(word string) bool {
+	return wordEndsWith(n.Suffix)
+}
+
+func (n EndsWith) String() string {
+	return "Ends with " + n.Suffix
+}
+
+func (n StartsAndEndsWith) Compile(ctx *Context) func(word string) bool {
+	return wordStartsAndEndsWith(n.Affix, n.Affix)
+}
+
+func (n StartsAndEndsWith) String() string {
+	return "Starts & ends with " + n.Affix
+}
+
+func (n Contains) Compile(ctx *Context) func(word string) bool {
+	return wordContains([]string{n.Substring})
+}
+
+func (n Contains) String() string {
+	return "Contains " + n.Substring
+}
+
+func (n LengthRange) Compile(ctx *Context) func(word string) bool {
+	switch {
+	case n.Max == noMaxLength:
+		return wordLengthGreaterThan(n.Min - 1)
+	case n.Min == n.Max:
+		return wordLengthEqualsTo(n.Min)
+	case n.Min == 0:
+		return wordLengthLessThan(n.Max + 1)
+	default:
+		return wordLengthBetween(n.Min, n.Max)
+	}
+}
+
+func (n LengthRange) String() string {
+	switch {
+	case n.Max == noMaxLength:
+		return fmt.Sprintf("%d letters or more", n.Min)
+	case n.Min == n.Max && numberWordOf(n.Min) != "":
+		return capitalize(numberWordOf(n.Min)) + " letter word"
+	case n.Min == 0:
+		return fmt.Sprintf("%d letters or fewer", n.Max)
+	default:
+		return fmt.Sprintf("Between %d and %d letters", n.Min, n.Max)
+	}
+}
+
+func (n CountAtLeast) Compile(ctx *Context) func(word string) bool {
+	if n.N == 2 {
+		return wordContainsMoreThanOne(n.Substring)
+	}
+	return func(word string) bool {
+		return strings.Count(word, n.Substring) >= n.N
+	}
+}
+
+func (n CountAtLeast) String() string {
+	if n.N == 2 {
+		return fmt.Sprintf("Multiple %s's", n.Substring)
+	}
+	return fmt.Sprintf("At least %d %s's", n.N, n.Substring)
+}
+
+func (n DoubleLetter) Compile(ctx *Context) func(word string) bool {
+	return wordHasDoubleLetter()
+}
+
+func (n DoubleLetter) String() string {
+	return "Double letter"
+}
+
+func (n Always) Compile(ctx *Context) func(word string) bool {
+	return func(word string) bool {
+		return true
+	}
+}
+
+func (n Always) String() string {
+	return "Infinity"
+}
+
+func (n Not) Compile(ctx *Context) func(word string) bool {
+	f := n.Node.Compile(ctx)
+	return func(word string) bool {
+		return !f(word)
+	}
+}
+
+// Prints "Does not contain x, y" when negating Contains nodes, and
+// "Not <clue>" otherwise.
+func (n Not) String() string {
+	if substrings, ok := containsList(n.Node, true); ok {
+		return "Does not contain " + strings.Join(substrings, ", ")
+	}
+	return "Not " + operand(n.Node, precedenceNot)
+}
+
+func (n And) Compile(ctx *Context) func(word string) bool {
+	funcs := compileAll(ctx, n.Nodes)
+	return func(word string) bool {
+		for _, f := range funcs {
+			if !f(word) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Prints "Contains x, y" when all operands are Contains nodes, and joins the
+// operands with "and" otherwise.
+func (n And) String() string {
+	if substrings, ok := containsList(n, false); ok {
+		return "Contains " + strings.Join(substrings, ", ")
+	}
+	return joinOperands(n.Nodes, " and ", precedenceAnd)
+}
+
+func (n Or) Compile(ctx *Context) func(word string) bool {
+	funcs := compileAll(ctx, n.Nodes)
+	return func(word string) bool {
+		for _, f := range funcs {
+			if f(word) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (n Or) String() string {
+	return joinOperands(n.Nodes, " or ", precedenceOr)
+}
+
+// Operator precedence, loosest first.
+const (
+	precedenceOr = iota
+	precedenceAnd
+	precedenceNot
+	precedenceAtom
+)
+
+func precedenceOf(n Node) int {
+	switch n := n.(type) {
+	case Or:
+		return precedenceOr
+	case And:
+		if _, ok := containsList(n, false); ok {
+			return precedenceAtom
+		}
+		return precedenceAnd
+	case Not:
+		if _, ok := containsList(n.Node, true); ok {
+			return precedenceAtom
+		}
+		return precedenceNot
+	default:
+		return precedenceAtom
+	}
+}
+
+// Prints n as an operand of an operator with the given precedence, adding
+// parentheses where needed.
+func operand(n Node, precedence int) string {
+	s := uncapitalize(n.String())
+	if precedenceOf(n) <= precedence && precedenceOf(n) != precedenceAtom {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+func joinOperands(nodes []Node, sep string, precedence int) string {
+	parts := make([]string, len(nodes))
+	for i, n := range nodes {
+		parts[i] = operand(n, precedence)
+	}
+	return capitalize(strings.Join(parts, sep))
+}
+
+// Returns the substrings of n if it is a Contains node, or an And (or, if
+// negated, an Or) of two or more Contains nodes.
+func containsList(n Node, negated bool) ([]string, bool) {
+	if c, ok := n.(Contains); ok {
+		return []string{c.Substring}, true
+	}
+
+	var nodes []Node
+	switch n := n.(type) {
+	case And:
+		if negated {
+			return nil, false
+		}
+		nodes = n.Nodes
+	case Or:
+		if !negated {
+			return nil, false
+		}
+		nodes = n.Nodes
+	default:
+		return nil, false
+	}
+	if len(nodes) < 2 {
+		return nil, false
+	}
+
+	substrings := make([]string, len(nodes))
+	for i, child := range nodes {
+		c, ok := child.(Contains)
+		if !ok {
+			return nil, false
+		}
+		substrings[i] = c.Substring
+	}
+	return substrings, true
+}
+
+func compileAll(ctx *Context, nodes []Node) []func(word string) bool {
+	funcs := make([]func(word string) bool, len(nodes))
+	for i, n := range nodes {
+		funcs[i] = n.Compile(ctx)
+	}
+	return funcs
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+func uncapitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
@@ -0,0 +1,71 @@
+package main
+
+import "math/bits"
+
+// Bitset is a set of word ids, with one bit for each word in a dictionary.
+// Bitsets combined with And, Or and Not must be for the same dictionary.
+type Bitset []uint64
+
+// Returns an empty set for a dictionary of n words.
+func newBitset(n int) Bitset {
+	return make(Bitset, (n+63)/64)
+}
+
+func (b Bitset) Set(id int) {
+	b[id/64] |= 1 << (id % 64)
+}
+
+func (b Bitset) Has(id int) bool {
+	return b[id/64]&(1<<(id%64)) != 0
+}
+
+// Returns the ids in both b and other.
+func (b Bitset) And(other Bitset) Bitset {
+	result := make(Bitset, len(b))
+	for i := range b {
+		result[i] = b[i] & other[i]
+	}
+	return result
+}
+
+// Returns the ids in either b or other.
+func (b Bitset) Or(other Bitset) Bitset {
+	result := make(Bitset, len(b))
+	for i := range b {
+		result[i] = b[i] | other[i]
+	}
+	return result
+}
+
+// Returns the ids below n that are not in b.
+func (b Bitset) Not(n int) Bitset {
+	result := make(Bitset, len(b))
+	for i := range b {
+		result[i] = ^b[i]
+	}
+	if n%64 != 0 {
+		result[len(result)-1] &= 1<<(n%64) - 1
+	}
+	return result
+}
+
+// Returns the number of ids in b.
+func (b Bitset) Count() int {
+	n := 0
+	for _, w := range b {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Returns the ids in b in ascending order.
+func (b Bitset) IDs() []int {
+	ids := make([]int, 0, b.Count())
+	for i, w := range b {
+		for w != 0 {
+			ids = append(ids, i*64+bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+	return ids
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func bitsetOf(n int, ids ...int) Bitset {
+	b := newBitset(n)
+	for _, id := range ids {
+		b.Set(id)
+	}
+	return b
+}
+
+func TestBitset(t *testing.T) {
+	const n = 130
+	a := bitsetOf(n, 1, 3, 64, 100, 129)
+	b := bitsetOf(n, 3, 4, 100, 128)
+
+	tests := []struct {
+		name     string
+		set      Bitset
+		expected []int
+	}{
+		{"ids", a, []int{1, 3, 64, 100, 129}},
+		{"and", a.And(b), []int{3, 100}},
+		{"or", a.Or(b), []int{1, 3, 4, 64, 100, 128, 129}},
+		{"not", bitsetOf(5, 0, 2).Not(5), []int{1, 3, 4}},
+		{"empty", newBitset(n), []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.set.IDs(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("IDs() = %v, want %v", got, tt.expected)
+			}
+			if got := tt.set.Count(); got != len(tt.expected) {
+				t.Errorf("Count() = %d, want %d", got, len(tt.expected))
+			}
+		})
+	}
+
+	if got := a.Not(n).Count(); got != n-5 {
+		t.Errorf("Not(%d).Count() = %d, want %d", n, got, n-5)
+	}
+	if !a.Has(64) || a.Has(65) {
+		t.Errorf("Has() disagrees with Set()")
+	}
+}
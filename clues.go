@@ -0,0 +1,542 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Shortest word that counts as hidden inside another word. Almost every word
+// contains one of the many two-letter words.
+const minHiddenWordLength = 3
+
+// Built-in clue families. Additional families can be registered the same way
+// from other files.
+func init() {
+	RegisterClue(Clue{
+		Pattern:     "starts with {text}",
+		Description: "The word must start with X.",
+		Examples:    []string{"Starts with mi"},
+		Build: func(args Args) (Node, error) {
+			return StartsWith{Prefix: args.Text(0)}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "ends with {text}",
+		Description: "The word must end with X.",
+		Examples:    []string{"Ends with m"},
+		Build: func(args Args) (Node, error) {
+			return EndsWith{Suffix: args.Text(0)}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "starts & ends with {text}",
+		Description: "The word must start and end with X.",
+		Examples:    []string{"Starts & ends with a"},
+		Build: func(args Args) (Node, error) {
+			return StartsAndEndsWith{Affix: args.Text(0)}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "contains the letter {text}",
+		Description: "The word must contain the letter X.",
+		Examples:    []string{"Contains the letter e"},
+		Build: func(args Args) (Node, error) {
+			return Contains{Substring: args.Text(0)}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "contains {list}",
+		Description: "The word must include each of X, Y, Z anywhere, or the exact sequence XY.",
+		Examples:    []string{"Contains e, l", "Contains el"},
+		Build: func(args Args) (Node, error) {
+			return containsAll(args.List(0)), nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "does not contain {list}",
+		Description: "The word must not include any of X, Y, Z.",
+		Examples:    []string{"Does not contain z, y"},
+		Build: func(args Args) (Node, error) {
+			return containsNone(args.List(0)), nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "between {n} and {n} letters",
+		Description: "The word must have between X and Y letters, inclusive.",
+		Examples:    []string{"Between 3 and 6 letters"},
+		Build: func(args Args) (Node, error) {
+			return LengthRange{Min: args.Int(0), Max: args.Int(1)}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "multiple letter {text}'s",
+		Description: "The word must contain more than one X.",
+		Examples:    []string{"Multiple letter l's"},
+		Build: func(args Args) (Node, error) {
+			return CountAtLeast{Substring: args.Text(0), N: 2}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "multiple {text}'s",
+		Description: "The word must contain more than one X.",
+		Examples:    []string{"Multiple l's"},
+		Build: func(args Args) (Node, error) {
+			return CountAtLeast{Substring: args.Text(0), N: 2}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "at least {n} {text}'s",
+		Description: "The word must contain at least N occurrences of X.",
+		Examples:    []string{"At least 3 e's"},
+		Build: func(args Args) (Node, error) {
+			return CountAtLeast{Substring: args.Text(1), N: args.Int(0)}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "double letter",
+		Description: "The word must include two identical letters in a row.",
+		Examples:    []string{"Double letter"},
+		Build: func(args Args) (Node, error) {
+			return DoubleLetter{}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "triple letter",
+		Description: "The word must include three identical letters in a row.",
+		Examples:    []string{"Triple letter"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordHasTripleLetter()
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "{nword} different double letters",
+		Description: "The word must include double letters of at least N different letters.",
+		Examples:    []string{"Two different double letters"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordHasDoubleLetters(args.Int(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "no repeated letters",
+		Description: "No letter may appear more than once.",
+		Examples:    []string{"No repeated letters"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordHasNoRepeatedLetters()
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "exactly {n} distinct letters",
+		Description: "The word must be made of exactly N different letters.",
+		Examples:    []string{"Exactly 4 distinct letters"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordHasDistinctLetters(args.Int(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "letter {text} appears exactly {n} times",
+		Description: "The word must contain exactly N occurrences of X.",
+		Examples:    []string{"Letter e appears exactly 2 times"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordContainsExactly(args.Text(0), args.Int(1))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "letters in alphabetical order",
+		Description: "Each letter must come no earlier in the alphabet than the one before it.",
+		Examples:    []string{"Letters in alphabetical order"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordLettersInAlphabeticalOrder()
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "letters in reverse alphabetical order",
+		Description: "Each letter must come no later in the alphabet than the one before it.",
+		Examples:    []string{"Letters in reverse alphabetical order"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordLettersInReverseAlphabeticalOrder()
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "first letter comes after last letter",
+		Description: "The first letter must come later in the alphabet than the last letter.",
+		Examples:    []string{"First letter comes after last letter"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordFirstLetterAfterLastLetter()
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "contains {nword} consecutive alphabet letters",
+		Description: "The word must contain a run of N consecutive alphabet letters, like abc or rst.",
+		Examples:    []string{"Contains three consecutive alphabet letters"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordHasAlphabeticalRun(args.Int(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "is a palindrome",
+		Description: "The word must read the same backwards.",
+		Examples:    []string{"Is a palindrome"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordIsPalindrome()
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "reversed is also a word",
+		Description: "The word spelled backwards must be a different word in the dictionary.",
+		Examples:    []string{"Reversed is also a word"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordReversedIsWord(ctx)
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "starts and ends with the same letter",
+		Description: "The first and last letters must be the same.",
+		Examples:    []string{"Starts and ends with the same letter"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordStartsAndEndsWithSameLetter()
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "contains another word",
+		Description: "The word must contain a shorter dictionary word of at least 3 letters.",
+		Examples:    []string{"Contains another word"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordContainsWord(ctx, minHiddenWordLength)
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "contains a {n}+ letter word",
+		Description: "The word must contain a shorter dictionary word of at least N letters.",
+		Examples:    []string{"Contains a 4+ letter word"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordContainsWord(ctx, args.Int(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "hides the word {text}",
+		Description: "The word must contain X inside a longer word.",
+		Examples:    []string{"Hides the word cat"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordHidesWord(args.Text(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "starts with a word",
+		Description: "The word must begin with a shorter dictionary word of at least 3 letters.",
+		Examples:    []string{"Starts with a word"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordStartsWithWord(ctx, minHiddenWordLength)
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "ends with a word",
+		Description: "The word must end with a shorter dictionary word of at least 3 letters.",
+		Examples:    []string{"Ends with a word"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordEndsWithWord(ctx, minHiddenWordLength)
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "remains a word when the first letter is removed",
+		Description: "The word without its first letter must be a dictionary word.",
+		Examples:    []string{"Remains a word when the first letter is removed"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordWithoutFirstLetterIsWord(ctx)
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "is an anagram of another word",
+		Description: "The letters of the word must spell a different dictionary word.",
+		Examples:    []string{"Is an anagram of another word"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordIsAnagramOfWord(ctx)
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "scrabble score of {n} or more",
+		Description: "The letters of the word must be worth at least N points.",
+		Examples:    []string{"Scrabble score of 15 or more"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordScoreAtLeast(ctx.LetterValues, args.Int(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "scrabble score of {n} or less",
+		Description: "The letters of the word must be worth at most N points.",
+		Examples:    []string{"Scrabble score of 6 or less"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordScoreAtMost(ctx.LetterValues, args.Int(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "contains a {n}-point letter",
+		Description: "The word must contain a letter worth N points.",
+		Examples:    []string{"Contains a 10-point letter"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordContainsLetterWorth(ctx.LetterValues, args.Int(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "uses only {n}-point letters",
+		Description: "Every letter of the word must be worth N points.",
+		Examples:    []string{"Uses only 1-point letters"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordUsesOnlyLettersWorth(ctx.LetterValues, args.Int(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "{n} letters or fewer",
+		Description: "The word must have at most N letters.",
+		Examples:    []string{"5 letters or fewer"},
+		Build: func(args Args) (Node, error) {
+			return LengthRange{Min: 0, Max: args.Int(0)}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "{n} letters or more",
+		Description: "The word must have at least N letters.",
+		Examples:    []string{"5 letters or more"},
+		Build: func(args Args) (Node, error) {
+			return LengthRange{Min: args.Int(0), Max: noMaxLength}, nil
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "{nword} letter word",
+		Description: "The word must have exactly N letters.",
+		Examples:    []string{"Five letter word"},
+		Build: func(args Args) (Node, error) {
+			n := args.Int(0)
+			return LengthRange{Min: n, Max: n}, nil
+		},
+	})
+	for _, class := range []struct {
+		name    string
+		letters string
+	}{
+		{"vowel", vowels},
+		{"consonant", consonants},
+	} {
+		RegisterClue(Clue{
+			Pattern:     "starts with a " + class.name,
+			Description: "The word must start with a " + class.name + ".",
+			Examples:    []string{"Starts with a " + class.name},
+			Func: func(ctx *Context, args Args) func(word string) bool {
+				return wordStartsWithOneOf(class.letters)
+			},
+		})
+		RegisterClue(Clue{
+			Pattern:     "ends with a " + class.name,
+			Description: "The word must end with a " + class.name + ".",
+			Examples:    []string{"Ends with a " + class.name},
+			Func: func(ctx *Context, args Args) func(word string) bool {
+				return wordEndsWithOneOf(class.letters)
+			},
+		})
+		RegisterClue(Clue{
+			Pattern:     "at least {n} " + class.name + "s",
+			Description: "The word must have at least N " + class.name + "s.",
+			Examples:    []string{"At least 3 " + class.name + "s"},
+			Func: func(ctx *Context, args Args) func(word string) bool {
+				return wordContainsAtLeast(class.letters, args.Int(0))
+			},
+		})
+		RegisterClue(Clue{
+			Pattern:     "{ordinal} letter is a " + class.name,
+			Description: "The letter at the given position must be a " + class.name + ".",
+			Examples:    []string{"Third letter is a " + class.name},
+			Func: func(ctx *Context, args Args) func(word string) bool {
+				return wordLetterAtIsOneOf(args.Int(0)-1, class.letters)
+			},
+		})
+		RegisterClue(Clue{
+			Pattern:     "letter at position {position} is a " + class.name,
+			Description: "The letter at position N must be a " + class.name + ".",
+			Examples:    []string{"Letter at position 3 is a " + class.name},
+			Func: func(ctx *Context, args Args) func(word string) bool {
+				return wordLetterAtIsOneOf(args.Int(0)-1, class.letters)
+			},
+		})
+	}
+	RegisterClue(Clue{
+		Pattern:     "{ordinal} letter is {text}",
+		Description: "The letter at the given position must be X.",
+		Examples:    []string{"Second letter is o", "2nd letter is o"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordLetterAtIsOneOf(args.Int(0)-1, args.Text(1))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "letter at position {position} is {text}",
+		Description: "The letter at position N must be X.",
+		Examples:    []string{"Letter at position 3 is e"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordLetterAtIsOneOf(args.Int(0)-1, args.Text(1))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "last letter is {text}",
+		Description: "The last letter must be X.",
+		Examples:    []string{"Last letter is e"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordLetterAtIsOneOf(-1, args.Text(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "{ordinal}-to-last letter is {text}",
+		Description: "The letter at the given position counting from the end must be X.",
+		Examples:    []string{"Third-to-last letter is e"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordLetterAtIsOneOf(-args.Int(0), args.Text(1))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "matches {text}",
+		Description: "The word must match X letter by letter, where _ stands for any letter.",
+		Examples:    []string{"Matches c_t"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordMatchesWildcard(args.Text(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "only uses letters from {range}",
+		Description: "Every letter of the word must fall in the given range of the alphabet.",
+		Examples:    []string{"Only uses letters from a-m"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordUsesOnly(args.Text(0))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "uses no letters from {range}",
+		Description: "No letter of the word may fall in the given range of the alphabet.",
+		Examples:    []string{"Uses no letters from n-z"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordDoesNotContain(strings.Split(args.Text(0), ""))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "only uses the letters {list}",
+		Description: "Every letter of the word must be one of X, Y, Z.",
+		Examples:    []string{"Only uses the letters a, b, c, e"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordUsesOnly(strings.Join(args.List(0), ""))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "uses none of the letters {list}",
+		Description: "No letter of the word may be one of X, Y, Z.",
+		Examples:    []string{"Uses none of the letters a, e"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordDoesNotContain(args.List(0))
+		},
+	})
+	for _, row := range []struct {
+		name    string
+		letters string
+	}{
+		{"top", keyboardTopRow},
+		{"middle", keyboardMiddleRow},
+		{"bottom", keyboardBottomRow},
+	} {
+		RegisterClue(Clue{
+			Pattern:     "uses only the " + row.name + " row of a keyboard",
+			Description: "The word must be typed using only the " + row.name + " row of a QWERTY keyboard.",
+			Examples:    []string{"Uses only the " + row.name + " row of a keyboard"},
+			Func: func(ctx *Context, args Args) func(word string) bool {
+				return wordUsesOnly(row.letters)
+			},
+		})
+	}
+	for _, hand := range []struct {
+		name    string
+		letters string
+	}{
+		{"left", leftHandLetters},
+		{"right", rightHandLetters},
+	} {
+		RegisterClue(Clue{
+			Pattern:     "typed with the " + hand.name + " hand",
+			Description: "The word must be typed using only the " + hand.name + " hand on a QWERTY keyboard.",
+			Examples:    []string{"Typed with the " + hand.name + " hand"},
+			Func: func(ctx *Context, args Args) func(word string) bool {
+				return wordUsesOnly(hand.letters)
+			},
+		})
+	}
+	RegisterClue(Clue{
+		Pattern:     "typed with one hand",
+		Description: "The word must be typed using only one hand on a QWERTY keyboard.",
+		Examples:    []string{"Typed with one hand"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			left := wordUsesOnly(leftHandLetters)
+			right := wordUsesOnly(rightHandLetters)
+			return func(word string) bool {
+				return left(word) || right(word)
+			}
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "matches pattern {pattern}",
+		Description: "The word must match a crossword-style pattern: ? is any letter, * any run of letters and [abc] or [a,b,c] any of the listed letters.",
+		Examples:    []string{"Matches pattern c?t", "Matches pattern *ing", "Matches pattern [aeiou]*[aeiou]"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			expr, _ := crosswordToRegexp(args.Text(0))
+			return wordMatchesRegexp(regexp.MustCompile(expr))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "matches regex {regexp}",
+		Description: "The word must match a regular expression written between slashes.",
+		Examples:    []string{"Matches regex /^(un|re).*ed$/"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordMatchesRegexp(regexp.MustCompile(args.Text(0)))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "no vowels except y",
+		Description: "The word must not contain a, e, i, o or u.",
+		Examples:    []string{"No vowels except y"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordDoesNotContain(strings.Split(vowels, ""))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "contains all five vowels",
+		Description: "The word must contain each of a, e, i, o and u.",
+		Examples:    []string{"Contains all five vowels"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return wordContains(strings.Split(vowels, ""))
+		},
+	})
+	RegisterClue(Clue{
+		Pattern:     "infinity",
+		Description: "Any word.",
+		Examples:    []string{"Infinity"},
+		Build: func(args Args) (Node, error) {
+			return Always{}, nil
+		},
+	})
+}
+
+// Wraps substrings in a Contains node, or an And of Contains nodes.
+func containsAll(substrings []string) Node {
+	if len(substrings) == 1 {
+		return Contains{Substring: substrings[0]}
+	}
+	nodes := make([]Node, len(substrings))
+	for i, s := range substrings {
+		nodes[i] = Contains{Substring: s}
+	}
+	return And{Nodes: nodes}
+}
+
+// Wraps substrings in a Not of a Contains node, or of an Or of Contains nodes.
+func containsNone(substrings []string) Node {
+	if len(substrings) == 1 {
+		return Not{Node: Contains{Substring: substrings[0]}}
+	}
+	nodes := make([]Node, len(substrings))
+	for i, s := range substrings {
+		nodes[i] = Contains{Substring: s}
+	}
+	return Not{Node: Or{Nodes: nodes}}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UnknownCodeError is returned when a clue code does not match any known kind.
+type UnknownCodeError struct {
+	Code string
+}
+
+func (e *UnknownCodeError) Error() string {
+	return fmt.Sprintf("cannot parse code %q", e.Code)
+}
+
+// Parses a machine-readable clue code like "starts_with:mi" into a Node.
+//
+// A code is a kind optionally followed by colon-separated arguments. Kinds are
+// case-insensitive and may use either underscores or dashes as separators.
+// Arguments are normalized like clue text.
+func parseCode(code string) (Node, error) {
+	kind, rest, hasArgs := strings.Cut(normalizeWord(code), ":")
+	kind = strings.ReplaceAll(kind, "-", "_")
+	var args []string
+	if hasArgs {
+		args = strings.Split(rest, ":")
+	}
+
+	atoi := func(s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, &MalformedNumberError{Clue: code, Number: s, Err: err}
+		}
+		return n, nil
+	}
+
+	switch {
+	case kind == "starts_with" && len(args) == 1:
+		return StartsWith{Prefix: args[0]}, nil
+	case kind == "ends_with" && len(args) == 1:
+		return EndsWith{Suffix: args[0]}, nil
+	case kind == "starts_and_ends_with" && len(args) == 1:
+		return StartsAndEndsWith{Affix: args[0]}, nil
+	case kind == "contains" && len(args) == 1:
+		return containsAll(splitList(args[0])), nil
+	case kind == "does_not_contain" && len(args) == 1:
+		return containsNone(splitList(args[0])), nil
+	case kind == "multiple" && len(args) == 1:
+		return CountAtLeast{Substring: args[0], N: 2}, nil
+	case kind == "double_letter" && len(args) == 0:
+		return DoubleLetter{}, nil
+	case kind == "length" && len(args) == 1:
+		n, err := atoi(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return LengthRange{Min: n, Max: n}, nil
+	case kind == "length_max" && len(args) == 1:
+		n, err := atoi(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return LengthRange{Min: 0, Max: n}, nil
+	case kind == "length_min" && len(args) == 1:
+		n, err := atoi(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return LengthRange{Min: n, Max: noMaxLength}, nil
+	case kind == "length_between" && len(args) == 2:
+		low, err := atoi(args[0])
+		if err != nil {
+			return nil, err
+		}
+		high, err := atoi(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return LengthRange{Min: low, Max: high}, nil
+	case kind == "infinity" && len(args) == 0:
+		return Always{}, nil
+	default:
+		return nil, &UnknownCodeError{Code: code}
+	}
+}
+
+// Splits a comma-separated list argument like "a, b", dropping surrounding
+// space and empty items as the clue text parser does.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Disagreement describes a clue whose code and text parse to predicates that
+// select different words from the dictionary.
+type Disagreement struct {
+	Name     string
+	Code     string
+	Count    int
+	Examples []string
+}
+
+const maxDisagreementExamples = 5
+
+// Evaluates the code-derived and text-derived predicate of every clue against
+// the dictionary and returns the clues for which they disagree. Clues where
+// either form cannot be parsed are skipped.
+func crossCheckPredicates(ctx *Context, predicates []Predicate) []Disagreement {
+	var disagreements []Disagreement
+	for _, p := range predicates {
+		if p.Code == "" {
+			continue
+		}
+		codeNode, err := parseCode(p.Code)
+		if err != nil {
+			continue
+		}
+		textNode, err := parseClue(p.Name)
+		if err != nil {
+			continue
+		}
+		codeFunc := codeNode.Compile(ctx)
+		textFunc := textNode.Compile(ctx)
+
+		d := Disagreement{Name: p.Name, Code: p.Code}
+		for _, w := range ctx.Words {
+			if codeFunc(w) != textFunc(w) {
+				d.Count++
+				if len(d.Examples) < maxDisagreementExamples {
+					d.Examples = append(d.Examples, w)
+				}
+			}
+		}
+		if d.Count > 0 {
+			disagreements = append(disagreements, d)
+		}
+	}
+	return disagreements
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "starts with",
+			code:     "starts_with:he",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "starts with - mismatch",
+			code:     "starts_with:xy",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "ends with",
+			code:     "ends_with:lo",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "starts and ends with",
+			code:     "starts_and_ends_with:a",
+			word:     "area",
+			expected: true,
+		},
+		{
+			name:     "contains list",
+			code:     "contains:e,l",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "contains list with spaces",
+			code:     "contains:e, l",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "does not contain list",
+			code:     "does_not_contain:z,e",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "multiple",
+			code:     "multiple:l",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "double letter",
+			code:     "double_letter",
+			word:     "world",
+			expected: false,
+		},
+		{
+			name:     "length",
+			code:     "length:5",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "length max",
+			code:     "length_max:4",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "length min",
+			code:     "length_min:5",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "length between",
+			code:     "length_between:2:4",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "uppercase kind with dashes",
+			code:     "STARTS-WITH:he",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "infinity",
+			code:     "infinity",
+			word:     "anyword",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := parseCode(tt.code)
+			if err != nil {
+				t.Fatalf("parseCode(%q) returned error: %v", tt.code, err)
+			}
+			result := n.Compile(emptyContext)(tt.word)
+			if result != tt.expected {
+				t.Errorf("parseCode(%q)(%q) = %v, want %v", tt.code, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCodeErrors(t *testing.T) {
+	var unknown *UnknownCodeError
+	for _, code := range []string{"", "rhymes_with:orange", "starts_with", "double_letter:x"} {
+		if _, err := parseCode(code); !errors.As(err, &unknown) {
+			t.Errorf("parseCode(%q) error = %v, want *UnknownCodeError", code, err)
+		}
+	}
+
+	var malformed *MalformedNumberError
+	if _, err := parseCode("length_between:x:4"); !errors.As(err, &malformed) {
+		t.Errorf("parseCode(%q) error = %v, want *MalformedNumberError", "length_between:x:4", err)
+	}
+}
+
+func TestCrossCheckPredicates(t *testing.T) {
+	ctx := newContext([]string{"abca", "afga", "afg", "baab"})
+	predicates := []Predicate{
+		{Name: "Starts with a", Code: "starts_with:a"},
+		{Name: "Ends with a", Code: "starts_with:a"},
+		{Name: "Ends with b", Code: ""},
+		{Name: "Rhymes with orange", Code: "starts_with:a"},
+	}
+
+	got := crossCheckPredicates(ctx, predicates)
+	want := []Disagreement{
+		{Name: "Ends with a", Code: "starts_with:a", Count: 1, Examples: []string{"afg"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("crossCheckPredicates() = %+v, want %+v", got, want)
+	}
+}
+
+// Checks every code in the API responses captured under testdata/games (see
+// the -save-game flag) parses and agrees with its clue text.
+func TestParseCodeFixtures(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "games", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Skip("no captured API responses in testdata/games; save one with -save-game")
+	}
+
+	ctx := newDictionaryContext(openDictionary(""))
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			body, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var data gameData
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Fatal(err)
+			}
+
+			var predicates []Predicate
+			for _, clue := range append(data.Rows, data.Columns...) {
+				if clue.Code == "" {
+					continue
+				}
+				if _, err := parseCode(clue.Code); err != nil {
+					t.Errorf("parseCode(%q) for %q returned error: %v", clue.Code, clue.Text, err)
+					continue
+				}
+				predicates = append(predicates, Predicate{Name: clue.Text, Code: clue.Code})
+			}
+			for _, d := range crossCheckPredicates(ctx, predicates) {
+				t.Errorf("code %q disagrees with %q on %d words, e.g. %v", d.Code, d.Name, d.Count, d.Examples)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+// Context carries the loaded dictionary, and indexes over it, to predicates
+// that need to consult it, such as "Reversed is also a word". Nodes receive
+// it when they are compiled; predicates that do not need it ignore it.
+//
+// Indexes other than those built by newDictionary are built on first use, so
+// predicates that do not use them do not pay for them.
+type Context struct {
+	*Dictionary
+	// LetterValues scores letters for clues like "Scrabble score of 15 or more".
+	LetterValues LetterValues
+}
+
+// An empty dictionary, used where no dictionary has been loaded.
+var emptyContext = newContext(nil)
+
+func newContext(words []string) *Context {
+	return newDictionaryContext(newDictionary(words))
+}
+
+func newDictionaryContext(d *Dictionary) *Context {
+	return &Context{Dictionary: d, LetterValues: scrabbleValues}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"unsafe"
+)
+
+// A binary dictionary file holds a normalized, deduplicated dictionary and its
+// indexes, so that it can be loaded without splitting and normalizing the
+// text file it was compiled from. All integers are little-endian:
+//
+//	magic         [4]byte "WGSD"
+//	version       uint32
+//	sourceSize    int64   size of the text file
+//	sourceModTime int64   modification time of the text file, in Unix nanoseconds
+//	payloadSize   uint64
+//	checksum      uint32  CRC-32 (IEEE) of the payload
+//	payload:
+//	  wordCount   uint32
+//	  offsets     [wordCount+1]uint32 into the word bytes
+//	  words       the words, concatenated
+//	  masks       [wordCount]uint32
+//	  lengths     uint32, the number of byLength bitsets
+//	  byLength    [lengths][(wordCount+63)/64]uint64
+//
+// The file is stale, and ignored, if the text file has changed since. The
+// version must be bumped whenever the layout or the normalization of words
+// changes.
+//
+// The file is memory-mapped when loaded and its words are read in place, so
+// compile-dict replaces the file rather than writing over it, which would
+// change the words of processes that have it mapped.
+const (
+	dictFileMagic   = "WGSD"
+	dictFileVersion = 1
+	dictHeaderSize  = 4 + 4 + 8 + 8 + 8 + 4
+)
+
+var errStaleDictionary = errors.New("dictionary file is out of date with its source")
+
+// Returns d encoded as a binary dictionary compiled from the text file
+// described by source.
+func encodeDictionary(d *Dictionary, source os.FileInfo) []byte {
+	var payload bytes.Buffer
+	le := binary.LittleEndian
+	payload.Write(le.AppendUint32(nil, uint32(len(d.Words))))
+	offset := uint32(0)
+	payload.Write(le.AppendUint32(nil, offset))
+	for _, w := range d.Words {
+		offset += uint32(len(w))
+		payload.Write(le.AppendUint32(nil, offset))
+	}
+	for _, w := range d.Words {
+		payload.WriteString(w)
+	}
+	for _, m := range d.masks {
+		payload.Write(le.AppendUint32(nil, m))
+	}
+	payload.Write(le.AppendUint32(nil, uint32(len(d.byLength))))
+	for _, b := range d.byLength {
+		for _, block := range b {
+			payload.Write(le.AppendUint64(nil, block))
+		}
+	}
+
+	header := []byte(dictFileMagic)
+	header = le.AppendUint32(header, dictFileVersion)
+	header = le.AppendUint64(header, uint64(source.Size()))
+	header = le.AppendUint64(header, uint64(source.ModTime().UnixNano()))
+	header = le.AppendUint64(header, uint64(payload.Len()))
+	header = le.AppendUint32(header, crc32.ChecksumIEEE(payload.Bytes()))
+	return append(header, payload.Bytes()...)
+}
+
+// Decodes a binary dictionary. Returns errStaleDictionary if source, the text
+// file it was compiled from, has changed since; source may be nil if the text
+// file is not available. The words of the dictionary refer to data, which must
+// not be modified or unmapped while the dictionary is in use.
+func decodeDictionary(data []byte, source os.FileInfo) (*Dictionary, error) {
+	le := binary.LittleEndian
+	if len(data) < dictHeaderSize || string(data[:4]) != dictFileMagic {
+		return nil, errors.New("not a dictionary file")
+	}
+	if version := le.Uint32(data[4:]); version != dictFileVersion {
+		return nil, fmt.Errorf("dictionary file version %d, want %d", version, dictFileVersion)
+	}
+	if source != nil && (int64(le.Uint64(data[8:])) != source.Size() || int64(le.Uint64(data[16:])) != source.ModTime().UnixNano()) {
+		return nil, errStaleDictionary
+	}
+	payload := data[dictHeaderSize:]
+	if le.Uint64(data[24:]) != uint64(len(payload)) || le.Uint32(data[32:]) != crc32.ChecksumIEEE(payload) {
+		return nil, errors.New("dictionary file is corrupt")
+	}
+
+	r := &payloadReader{data: payload}
+	count := int(r.uint32())
+	offsets := make([]int, count+1)
+	for i := range offsets {
+		offsets[i] = int(r.uint32())
+	}
+	text := r.bytes(offsets[count])
+
+	d := &Dictionary{
+		Words: make([]string, count),
+		ids:   make(map[string]int, count),
+		masks: make([]uint32, count),
+	}
+	for id := range d.Words {
+		if offsets[id] > offsets[id+1] || offsets[id+1] > len(text) {
+			return nil, errors.New("dictionary file is corrupt")
+		}
+		d.Words[id] = unsafe.String(unsafe.SliceData(text[offsets[id]:]), offsets[id+1]-offsets[id])
+		d.ids[d.Words[id]] = id
+	}
+	for id := range d.masks {
+		d.masks[id] = r.uint32()
+	}
+	d.byLength = make([]Bitset, r.uint32())
+	for i := range d.byLength {
+		d.byLength[i] = d.newBitset()
+		for j := range d.byLength[i] {
+			d.byLength[i][j] = r.uint64()
+		}
+	}
+	if r.err != nil || len(r.data) != 0 {
+		return nil, errors.New("dictionary file is corrupt")
+	}
+	return d, nil
+}
+
+// Reads little-endian values from a payload, recording an error instead of
+// failing if the payload is too short.
+type payloadReader struct {
+	data []byte
+	err  error
+}
+
+func (r *payloadReader) bytes(n int) []byte {
+	if r.err != nil || n > len(r.data) {
+		r.err = errors.New("unexpected end of dictionary file")
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *payloadReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *payloadReader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+// Compiles the text dictionary at textPath into a binary dictionary at binPath.
+// The binary dictionary is written to a temporary file that then replaces
+// binPath, leaving any mapping of the previous file intact.
+func compileDictionary(textPath, binPath string) error {
+	source, err := os.Stat(textPath)
+	if err != nil {
+		return err
+	}
+	d := newDictionary(loadWords(textPath))
+
+	f, err := os.CreateTemp(filepath.Dir(binPath), filepath.Base(binPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(encodeDictionary(d, source)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(0644); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), binPath)
+}
+
+// Loads the binary dictionary at binPath, falling back to the text dictionary
+// at textPath if the binary one is missing, stale or corrupt. The binary
+// dictionary stays mapped for the life of the process, as its words are read
+// from the mapping.
+func loadDictionary(textPath, binPath string) *Dictionary {
+	source, err := os.Stat(textPath)
+	if err != nil {
+		source = nil
+	}
+
+	data, unmap, err := mapFile(binPath)
+	if err == nil {
+		var d *Dictionary
+		d, err = decodeDictionary(data, source)
+		if err == nil {
+			fmt.Println("Loaded dictionary from", binPath)
+			return d
+		}
+		check(unmap())
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		fmt.Printf("Ignoring %s: %v\n", binPath, err)
+	}
+	return newDictionary(loadWords(textPath))
+}
+
+// Returns the path of the binary dictionary compiled from the text dictionary at textPath.
+func binaryDictionaryPath(textPath string) string {
+	return strings.TrimSuffix(textPath, ".txt") + ".bin"
+}
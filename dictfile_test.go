@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeTestDictionary(t *testing.T, words string) (textPath, binPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	textPath = filepath.Join(dir, "words.txt")
+	if err := os.WriteFile(textPath, []byte(words), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return textPath, binaryDictionaryPath(textPath)
+}
+
+func TestDictionaryFileRoundTrip(t *testing.T) {
+	textPath, binPath := writeTestDictionary(t, "Apple\nbanana\r\napple\n\ncafé\nkiwi\n")
+	if err := compileDictionary(textPath, binPath); err != nil {
+		t.Fatalf("compileDictionary returned error: %v", err)
+	}
+
+	d := loadDictionary(textPath, binPath)
+	want := newDictionary(loadWords(textPath))
+	if !reflect.DeepEqual(d.Words, want.Words) {
+		t.Errorf("Words = %q, want %q", d.Words, want.Words)
+	}
+	if !reflect.DeepEqual(d.masks, want.masks) {
+		t.Errorf("masks = %v, want %v", d.masks, want.masks)
+	}
+	if !reflect.DeepEqual(d.byLength, want.byLength) {
+		t.Errorf("byLength = %v, want %v", d.byLength, want.byLength)
+	}
+	if !d.IsWord("café") || d.IsWord("Apple") {
+		t.Errorf("IsWord disagrees with Words %q", d.Words)
+	}
+}
+
+func TestCompileDictionaryKeepsLoadedWords(t *testing.T) {
+	textPath, binPath := writeTestDictionary(t, "apple\nbanana\n")
+	if err := compileDictionary(textPath, binPath); err != nil {
+		t.Fatalf("compileDictionary returned error: %v", err)
+	}
+	d := loadDictionary(textPath, binPath)
+
+	if err := os.WriteFile(textPath, []byte("cherry\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := compileDictionary(textPath, binPath); err != nil {
+		t.Fatalf("compileDictionary returned error: %v", err)
+	}
+	if !reflect.DeepEqual(d.Words, []string{"apple", "banana"}) {
+		t.Errorf("Words after recompiling = %q, want %q", d.Words, []string{"apple", "banana"})
+	}
+	if d := loadDictionary(textPath, binPath); !reflect.DeepEqual(d.Words, []string{"cherry"}) {
+		t.Errorf("loadDictionary after recompiling = %q, want %q", d.Words, []string{"cherry"})
+	}
+	if tmp, _ := filepath.Glob(binPath + ".*.tmp"); len(tmp) != 0 {
+		t.Errorf("compileDictionary left temporary files %q", tmp)
+	}
+}
+
+func TestDecodeDictionaryErrors(t *testing.T) {
+	textPath, binPath := writeTestDictionary(t, "apple\nbanana\n")
+	if err := compileDictionary(textPath, binPath); err != nil {
+		t.Fatalf("compileDictionary returned error: %v", err)
+	}
+	data, err := os.ReadFile(binPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source, err := os.Stat(textPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := decodeDictionary(data, source); err != nil {
+		t.Fatalf("decodeDictionary returned error: %v", err)
+	}
+	if _, err := decodeDictionary(data, nil); err != nil {
+		t.Errorf("decodeDictionary without source returned error: %v", err)
+	}
+
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(textPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := os.Stat(textPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decodeDictionary(data, changed); !errors.Is(err, errStaleDictionary) {
+		t.Errorf("decodeDictionary of stale file error = %v, want errStaleDictionary", err)
+	}
+
+	corrupt := func(i int, b byte) []byte {
+		c := append([]byte(nil), data...)
+		c[i] = b
+		return c
+	}
+	for name, bad := range map[string][]byte{
+		"empty":     nil,
+		"magic":     corrupt(0, 'X'),
+		"version":   corrupt(4, dictFileVersion+1),
+		"checksum":  corrupt(len(data)-1, data[len(data)-1]^1),
+		"truncated": data[:len(data)-1],
+	} {
+		if _, err := decodeDictionary(bad, source); err == nil {
+			t.Errorf("decodeDictionary of %s file returned no error", name)
+		}
+	}
+}
+
+func TestLoadDictionaryFallback(t *testing.T) {
+	textPath, binPath := writeTestDictionary(t, "apple\nbanana\n")
+
+	if d := loadDictionary(textPath, binPath); !reflect.DeepEqual(d.Words, []string{"apple", "banana"}) {
+		t.Errorf("loadDictionary without binary = %q", d.Words)
+	}
+
+	if err := compileDictionary(textPath, binPath); err != nil {
+		t.Fatalf("compileDictionary returned error: %v", err)
+	}
+	if err := os.WriteFile(textPath, []byte("cherry\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if d := loadDictionary(textPath, binPath); !reflect.DeepEqual(d.Words, []string{"cherry"}) {
+		t.Errorf("loadDictionary with stale binary = %q, want %q", d.Words, []string{"cherry"})
+	}
+}
+
+func TestOpenDictionary(t *testing.T) {
+	embedded := openDictionary("")
+	if len(embedded.Words) == 0 || !embedded.IsWord("hello") {
+		t.Errorf("openDictionary(\"\") has %d words and no %q", len(embedded.Words), "hello")
+	}
+
+	textPath, _ := writeTestDictionary(t, "apple\nbanana\n")
+	if d := openDictionary(textPath); !reflect.DeepEqual(d.Words, []string{"apple", "banana"}) {
+		t.Errorf("openDictionary(%q) = %q", textPath, d.Words)
+	}
+}
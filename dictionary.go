@@ -0,0 +1,410 @@
+package main
+
+import (
+	"cmp"
+	"encoding/json"
+	"iter"
+	"math/bits"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Dictionary is the loaded word list with indexes over it, so that common
+// clues like "Starts with a" or "Five letter word" can be answered without
+// checking every word.
+//
+// Words are identified by their index in Words, and the indexes are Bitsets
+// of those ids.
+type Dictionary struct {
+	// Words is the dictionary in file order, without duplicates or empty words.
+	Words []string
+	// Lists names the word lists the dictionary was merged from, if any, and
+	// lists holds the words of each.
+	Lists []string
+	lists []Bitset
+	// rarities holds the rarity of each word, if frequencies have been set.
+	rarities []float64
+
+	ids      map[string]int
+	byLength []Bitset
+	// masks holds, for each word, a bitmask of the letters a to z it contains.
+	masks []uint32
+
+	triesOnce sync.Once
+	prefixes  *Trie
+	// suffixes holds the words spelled backwards.
+	suffixes *Trie
+
+	anagramsOnce sync.Once
+	anagrams     map[string]int
+}
+
+func newDictionary(words []string) *Dictionary {
+	d := &Dictionary{ids: make(map[string]int, len(words))}
+	for _, w := range words {
+		if _, ok := d.ids[w]; ok || w == "" {
+			continue
+		}
+		d.ids[w] = len(d.Words)
+		d.Words = append(d.Words, w)
+	}
+
+	d.masks = make([]uint32, len(d.Words))
+	for id, w := range d.Words {
+		length := utf8.RuneCountInString(w)
+		for len(d.byLength) <= length {
+			d.byLength = append(d.byLength, d.newBitset())
+		}
+		d.byLength[length].Set(id)
+		d.masks[id] = letterMask(w)
+	}
+	return d
+}
+
+// Merges the named word lists into a single dictionary, in which each word
+// is tagged with the lists that contain it. Words are in the order of the
+// first list containing them.
+func mergeDictionaries(names []string, dicts []*Dictionary) *Dictionary {
+	d := dicts[0]
+	if len(dicts) > 1 {
+		var words []string
+		for _, list := range dicts {
+			words = append(words, list.Words...)
+		}
+		d = newDictionary(words)
+	}
+
+	d.Lists = names
+	d.lists = make([]Bitset, len(dicts))
+	for i, list := range dicts {
+		d.lists[i] = d.newBitset()
+		for _, w := range list.Words {
+			d.lists[i].Set(d.ids[w])
+		}
+	}
+	return d
+}
+
+// Sets the rarity of each word from freqs. Words missing from freqs are
+// treated as the rarest.
+func (d *Dictionary) setFrequencies(freqs Frequencies) {
+	var maxCount int64
+	for _, w := range d.Words {
+		maxCount = max(maxCount, freqs[w])
+	}
+	d.rarities = make([]float64, len(d.Words))
+	for id, w := range d.Words {
+		d.rarities[id] = rarity(freqs[w], maxCount)
+	}
+}
+
+// Returns an empty set of words in the dictionary.
+func (d *Dictionary) newBitset() Bitset {
+	return newBitset(len(d.Words))
+}
+
+// Builds the prefix and suffix tries on first use.
+func (d *Dictionary) tries() (prefixes, suffixes *Trie) {
+	d.triesOnce.Do(func() {
+		reversed := make([][]rune, len(d.Words))
+		ids := make([]int, len(d.Words))
+		for id, w := range d.Words {
+			reversed[id] = []rune(w)
+			slices.Reverse(reversed[id])
+			ids[id] = id
+		}
+
+		d.prefixes, d.suffixes = newTrie(), newTrie()
+		slices.SortFunc(ids, func(a, b int) int {
+			return strings.Compare(d.Words[a], d.Words[b])
+		})
+		for _, id := range ids {
+			d.prefixes.Insert([]rune(d.Words[id]), id)
+		}
+		slices.SortFunc(ids, func(a, b int) int {
+			return slices.Compare(reversed[a], reversed[b])
+		})
+		for _, id := range ids {
+			d.suffixes.Insert(reversed[id], id)
+		}
+	})
+	return d.prefixes, d.suffixes
+}
+
+// Returns the words beginning with prefix, in alphabetical order.
+func (d *Dictionary) WordsWithPrefix(prefix string) iter.Seq[string] {
+	prefixes, _ := d.tries()
+	return d.wordsOf(prefixes.WithPrefix([]rune(prefix)))
+}
+
+// Returns the words ending with suffix, in alphabetical order of the words
+// spelled backwards.
+func (d *Dictionary) WordsWithSuffix(suffix string) iter.Seq[string] {
+	_, suffixes := d.tries()
+	letters := []rune(suffix)
+	slices.Reverse(letters)
+	return d.wordsOf(suffixes.WithPrefix(letters))
+}
+
+// Returns the words with the given ids.
+func (d *Dictionary) wordsOf(ids iter.Seq[int]) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for id := range ids {
+			if !yield(d.Words[id]) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the words beginning with prefix.
+func (d *Dictionary) withPrefix(prefix string) Bitset {
+	prefixes, _ := d.tries()
+	return d.bitsetOf(prefixes.WithPrefix([]rune(prefix)))
+}
+
+// Returns the words ending with suffix.
+func (d *Dictionary) withSuffix(suffix string) Bitset {
+	_, suffixes := d.tries()
+	letters := []rune(suffix)
+	slices.Reverse(letters)
+	return d.bitsetOf(suffixes.WithPrefix(letters))
+}
+
+// Returns the set of the given ids.
+func (d *Dictionary) bitsetOf(ids iter.Seq[int]) Bitset {
+	b := d.newBitset()
+	for id := range ids {
+		b.Set(id)
+	}
+	return b
+}
+
+// Returns a bitmask of the letters a to z in s. Other characters are ignored.
+func letterMask(s string) uint32 {
+	var mask uint32
+	for i := range len(s) {
+		if c := s[i]; c >= 'a' && c <= 'z' {
+			mask |= 1 << (c - 'a')
+		}
+	}
+	return mask
+}
+
+// Reports whether word is in the dictionary.
+func (d *Dictionary) IsWord(word string) bool {
+	_, ok := d.ids[word]
+	return ok
+}
+
+// Returns the number of dictionary words spelled with exactly the letters of
+// word, including word itself if it is in the dictionary.
+func (d *Dictionary) AnagramCount(word string) int {
+	d.anagramsOnce.Do(func() {
+		d.anagrams = make(map[string]int, len(d.Words))
+		for _, w := range d.Words {
+			d.anagrams[anagramKey(w)]++
+		}
+	})
+	return d.anagrams[anagramKey(word)]
+}
+
+// Returns the letters of word in sorted order, which is the same for all
+// anagrams of word.
+func anagramKey(word string) string {
+	letters := []rune(word)
+	slices.Sort(letters)
+	return string(letters)
+}
+
+// Returns the words in b.
+func (d *Dictionary) wordsIn(b Bitset) []string {
+	words := make([]string, 0, b.Count())
+	for _, id := range b.IDs() {
+		words = append(words, d.Words[id])
+	}
+	return words
+}
+
+// Returns the words n matches, compiling n against ctx. Clues the indexes can
+// answer are looked up directly; the others are evaluated once per word, or
+// once per candidate if the indexes can narrow them down. The returned set is
+// newly allocated and may be modified by the caller.
+func (c *Context) Select(n Node) Bitset {
+	return c.SelectParallel(n, 1)
+}
+
+// Returns the same words as Select, evaluating n over shards of the dictionary
+// on up to workers goroutines. The compiled predicate must be safe to call
+// concurrently, which all registered clues are.
+func (c *Context) SelectParallel(n Node, workers int) Bitset {
+	candidates, exact, ok := c.candidates(n)
+	if ok && exact {
+		return candidates
+	}
+
+	f := n.Compile(c)
+	matches := c.newBitset()
+	workers = max(workers, 1)
+	// Shards cover whole blocks of the bitset, so that no two workers write
+	// to the same block.
+	blocks := len(matches)
+	shardBlocks := max(1, (blocks+workers*shardsPerWorker-1)/(workers*shardsPerWorker))
+	shards := (blocks + shardBlocks - 1) / shardBlocks
+	parallelFor(shards, workers, func(shard int) {
+		for block := shard * shardBlocks; block < min((shard+1)*shardBlocks, blocks); block++ {
+			if !ok {
+				for id := block * 64; id < min((block+1)*64, len(c.Words)); id++ {
+					if f(c.Words[id]) {
+						matches.Set(id)
+					}
+				}
+				continue
+			}
+			for w := candidates[block]; w != 0; w &= w - 1 {
+				id := block*64 + bits.TrailingZeros64(w)
+				if f(c.Words[id]) {
+					matches.Set(id)
+				}
+			}
+		}
+	})
+	return matches
+}
+
+// Word is a word of a result, with the names of the word lists that contain
+// it and, if word frequencies were loaded, its rarity from 0 to 1. Lists is
+// empty if every list contains the word.
+type Word struct {
+	Word   string   `json:"word"`
+	Lists  []string `json:"lists,omitempty"`
+	Rarity *float64 `json:"rarity,omitempty"`
+}
+
+// Marshals w as a bare string if it is in every list and has no rarity, as
+// all words are when a single list is used without frequencies, which keeps
+// results as small as a plain list of words.
+func (w Word) MarshalJSON() ([]byte, error) {
+	if len(w.Lists) == 0 && w.Rarity == nil {
+		return json.Marshal(w.Word)
+	}
+	type word Word
+	return json.Marshal(word(w))
+}
+
+// Returns the words in b, tagged with the lists that contain them unless all
+// of them do. If
+// frequencies have been set, the words include their rarity and are ranked
+// rarest first; otherwise they are in dictionary order.
+func (d *Dictionary) taggedWordsIn(b Bitset) []Word {
+	// Words in the same lists share the slice of list names.
+	tags := make(map[string][]string)
+	var key []byte
+	words := make([]Word, 0, b.Count())
+	for _, id := range b.IDs() {
+		key = key[:0]
+		for i, list := range d.lists {
+			if list.Has(id) {
+				key = append(key, byte(i))
+			}
+		}
+		lists, ok := tags[string(key)]
+		if !ok && len(key) < len(d.Lists) {
+			lists = make([]string, len(key))
+			for j, i := range key {
+				lists[j] = d.Lists[i]
+			}
+			tags[string(key)] = lists
+		}
+		word := Word{Word: d.Words[id], Lists: lists}
+		if d.rarities != nil {
+			word.Rarity = &d.rarities[id]
+		}
+		words = append(words, word)
+	}
+
+	if d.rarities != nil {
+		slices.SortStableFunc(words, func(a, b Word) int {
+			return cmp.Compare(*b.Rarity, *a.Rarity)
+		})
+	}
+	return words
+}
+
+// Returns the words that may match n and whether all of them do. ok is false
+// if the indexes cannot narrow n down, in which case every word must be
+// checked.
+func (d *Dictionary) candidates(n Node) (candidates Bitset, exact, ok bool) {
+	switch n := n.(type) {
+	case LengthRange:
+		candidates = d.newBitset()
+		maxLength := n.Max
+		if maxLength == noMaxLength || maxLength >= len(d.byLength) {
+			maxLength = len(d.byLength) - 1
+		}
+		for length := max(n.Min, 0); length <= maxLength; length++ {
+			candidates = candidates.Or(d.byLength[length])
+		}
+		return candidates, true, true
+	case StartsWith:
+		return d.withPrefix(n.Prefix), true, true
+	case EndsWith:
+		return d.withSuffix(n.Suffix), true, true
+	case StartsAndEndsWith:
+		return d.withPrefix(n.Affix).And(d.withSuffix(n.Affix)), true, true
+	case Contains:
+		mask := letterMask(n.Substring)
+		if mask == 0 {
+			return nil, false, false
+		}
+		candidates = d.newBitset()
+		for id, m := range d.masks {
+			if m&mask == mask {
+				candidates.Set(id)
+			}
+		}
+		return candidates, isSingleLetter(n.Substring), true
+	case Not:
+		candidates, exact, ok := d.candidates(n.Node)
+		if !ok || !exact {
+			return nil, false, false
+		}
+		return candidates.Not(len(d.Words)), true, true
+	case And:
+		exact, ok := true, false
+		for _, child := range n.Nodes {
+			childCandidates, childExact, childOK := d.candidates(child)
+			if !childOK {
+				exact = false
+				continue
+			}
+			if ok {
+				candidates = candidates.And(childCandidates)
+			} else {
+				candidates, ok = childCandidates, true
+			}
+			exact = exact && childExact
+		}
+		return candidates, exact, ok
+	case Or:
+		candidates, exact = d.newBitset(), true
+		for _, child := range n.Nodes {
+			childCandidates, childExact, childOK := d.candidates(child)
+			if !childOK {
+				return nil, false, false
+			}
+			candidates = candidates.Or(childCandidates)
+			exact = exact && childExact
+		}
+		return candidates, exact, true
+	default:
+		return nil, false, false
+	}
+}
+
+// Reports whether s is a single letter a to z, which letterMask indexes exactly.
+func isSingleLetter(s string) bool {
+	return len(s) == 1 && letterMask(s) != 0
+}
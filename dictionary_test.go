@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestNewDictionary(t *testing.T) {
+	d := newDictionary([]string{"hello", "", "world", "hello", "café"})
+
+	if want := []string{"hello", "world", "café"}; !reflect.DeepEqual(d.Words, want) {
+		t.Errorf("Words = %q, want %q", d.Words, want)
+	}
+	for word, expected := range map[string]bool{"hello": true, "café": true, "": false, "cafe": false} {
+		if result := d.IsWord(word); result != expected {
+			t.Errorf("IsWord(%q) = %v, want %v", word, result, expected)
+		}
+	}
+	if got, want := d.byLength[5].IDs(), []int{0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("byLength[5] = %v, want %v", got, want)
+	}
+	if got, want := d.byLength[4].IDs(), []int{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("byLength[4] = %v, want %v", got, want)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	ctx := newContext([]string{
+		"apple", "banana", "cherry", "date", "elderberry", "fig", "grape", "kiwi",
+		"lemon", "mango", "a", "aa", "area", "café", "école", "zebra", "stressed", "desserts",
+	})
+	clues := []string{
+		"Starts with a",
+		"Starts with ap",
+		"Starts with é",
+		"Ends with e",
+		"Ends with ry",
+		"Starts & ends with a",
+		"Starts & ends with are",
+		"Contains e",
+		"Contains er",
+		"Contains é",
+		"Contains a, e",
+		"Does not contain e",
+		"Does not contain a, e",
+		"Five letter word",
+		"Between 3 and 4 letters",
+		"8 letters or more",
+		"Not 5 letters or fewer",
+		"Starts with a or ends with y",
+		"Starts with a or double letter",
+		"Five letter word and double letter",
+		"Not (starts with a or contains er)",
+		"Reversed is also a word",
+		"Infinity",
+	}
+
+	for _, clue := range clues {
+		t.Run(clue, func(t *testing.T) {
+			n, err := parseClue(clue)
+			if err != nil {
+				t.Fatalf("parseClue(%q) returned error: %v", clue, err)
+			}
+			var want []string
+			f := n.Compile(ctx)
+			for _, w := range ctx.Words {
+				if f(w) {
+					want = append(want, w)
+				}
+			}
+			got := ctx.wordsIn(ctx.Select(n))
+			if len(got) != 0 || len(want) != 0 {
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("Select(%q) = %q, want %q", clue, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectEvaluatesCandidatesOnce(t *testing.T) {
+	saved := clueRegistry
+	defer func() { clueRegistry = saved }()
+
+	calls := make(map[string]int)
+	RegisterClue(Clue{
+		Pattern:     "counted",
+		Description: "Matches every word, counting the calls.",
+		Examples:    []string{"Counted"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			return func(word string) bool {
+				calls[word]++
+				return true
+			}
+		},
+	})
+
+	ctx := newContext([]string{"apple", "banana", "apple", "avocado", "cherry"})
+	n, err := parseClue("Starts with a and counted")
+	if err != nil {
+		t.Fatalf("parseClue returned error: %v", err)
+	}
+	got := ctx.wordsIn(ctx.Select(n))
+	if want := []string{"apple", "avocado"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Select() = %q, want %q", got, want)
+	}
+	if want := map[string]int{"apple": 1, "avocado": 1}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("predicate calls = %v, want %v", calls, want)
+	}
+}
+
+func TestMergeDictionaries(t *testing.T) {
+	d := mergeDictionaries(
+		[]string{"sowpods", "twl", "team"},
+		[]*Dictionary{
+			newDictionary([]string{"qi", "za", "zzz"}),
+			newDictionary([]string{"qi", "za", "ok"}),
+			newDictionary([]string{"ok", "zzz", "qi"}),
+		},
+	)
+
+	if want := []string{"qi", "za", "zzz", "ok"}; !reflect.DeepEqual(d.Words, want) {
+		t.Errorf("Words = %q, want %q", d.Words, want)
+	}
+	got := d.taggedWordsIn(d.newBitset().Not(len(d.Words)))
+	want := []Word{
+		{Word: "qi"},
+		{Word: "za", Lists: []string{"sowpods", "twl"}},
+		{Word: "zzz", Lists: []string{"sowpods", "team"}},
+		{Word: "ok", Lists: []string{"twl", "team"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("taggedWordsIn() = %+v, want %+v", got, want)
+	}
+
+	single := mergeDictionaries([]string{"sowpods"}, []*Dictionary{newDictionary([]string{"qi"})})
+	if got, want := single.taggedWordsIn(single.newBitset().Not(1)), []Word{{Word: "qi"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("taggedWordsIn() of a single list = %+v, want %+v", got, want)
+	}
+}
+
+func TestWordMarshalJSON(t *testing.T) {
+	rarity := 0.5
+	tests := []struct {
+		word     Word
+		expected string
+	}{
+		{Word{Word: "qi"}, `"qi"`},
+		{Word{Word: "za", Lists: []string{"twl"}}, `{"word":"za","lists":["twl"]}`},
+		{Word{Word: "ok", Rarity: &rarity}, `{"word":"ok","rarity":0.5}`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.word)
+		if err != nil {
+			t.Fatalf("json.Marshal(%+v) returned error: %v", tt.word, err)
+		}
+		if string(got) != tt.expected {
+			t.Errorf("json.Marshal(%+v) = %s, want %s", tt.word, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Frequencies maps words to how often they occur, e.g. in a corpus.
+type Frequencies map[string]int64
+
+// Loads a frequency file with one word and its count per line, e.g.
+// "the 23135851162". Words are normalized like the dictionary; the counts of
+// words that normalize the same are added up.
+func loadFrequencies(path string) (Frequencies, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	freqs := make(Frequencies)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: expected a word and its count", path, line)
+		}
+		count, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if count < 0 {
+			return nil, fmt.Errorf("%s:%d: negative count %d", path, line, count)
+		}
+		freqs[normalizeWord(fields[0])] += count
+	}
+	return freqs, scanner.Err()
+}
+
+// Returns the rarity of a word that occurs count times, from 0 for the most
+// frequent word to 1 for words that do not occur at all. Rarity falls with the
+// logarithm of the count, as word frequencies span many orders of magnitude.
+func rarity(count, maxCount int64) float64 {
+	if count <= 0 || maxCount <= 0 {
+		return 1
+	}
+	r := 1 - math.Log1p(float64(count))/math.Log1p(float64(maxCount))
+	// Three decimals are plenty to rank words and keep the results file small.
+	return math.Round(r*1000) / 1000
+}
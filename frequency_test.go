@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadFrequencies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "frequencies.txt")
+	if err := os.WriteFile(path, []byte("the 1000\nThe 10\n\ncafé 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	freqs, err := loadFrequencies(path)
+	if err != nil {
+		t.Fatalf("loadFrequencies(%q) returned error: %v", path, err)
+	}
+	if want := (Frequencies{"the": 1010, "café": 3}); !reflect.DeepEqual(freqs, want) {
+		t.Errorf("loadFrequencies(%q) = %v, want %v", path, freqs, want)
+	}
+
+	for _, content := range []string{"the\n", "the 1 2\n", "the many\n", "the -1\n"} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadFrequencies(path); err == nil {
+			t.Errorf("loadFrequencies with content %q returned no error", content)
+		}
+	}
+
+	if _, err := loadFrequencies(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("loadFrequencies of a missing file returned no error")
+	}
+}
+
+func TestRarity(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int64
+		maxCount int64
+		expected float64
+	}{
+		{"most frequent", 1000, 1000, 0},
+		{"unseen", 0, 1000, 1},
+		{"no frequencies", 0, 0, 1},
+		{"in between", 31, 1023, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := rarity(tt.count, tt.maxCount); result != tt.expected {
+				t.Errorf("rarity(%d, %d) = %v, want %v", tt.count, tt.maxCount, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRankedWords(t *testing.T) {
+	d := mergeDictionaries([]string{"sowpods"}, []*Dictionary{newDictionary([]string{"apple", "aardvark", "ant", "axolotl"})})
+	d.setFrequencies(Frequencies{"apple": 1000, "ant": 31, "unused": 5})
+
+	var got []string
+	for _, w := range d.taggedWordsIn(d.newBitset().Not(len(d.Words))) {
+		got = append(got, w.Word)
+	}
+	if want := []string{"aardvark", "axolotl", "ant", "apple"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ranked words = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// LetterValues maps letters to their point values. Letters missing from the
+// table are worth nothing.
+type LetterValues map[rune]int
+
+var scrabbleValues = LetterValues{
+	'a': 1, 'b': 3, 'c': 3, 'd': 2, 'e': 1, 'f': 4, 'g': 2, 'h': 4, 'i': 1,
+	'j': 8, 'k': 5, 'l': 1, 'm': 3, 'n': 1, 'o': 1, 'p': 3, 'q': 10, 'r': 1,
+	's': 1, 't': 1, 'u': 1, 'v': 4, 'w': 4, 'x': 8, 'y': 4, 'z': 10,
+}
+
+var wordsWithFriendsValues = LetterValues{
+	'a': 1, 'b': 4, 'c': 4, 'd': 2, 'e': 1, 'f': 4, 'g': 3, 'h': 3, 'i': 1,
+	'j': 10, 'k': 5, 'l': 2, 'm': 4, 'n': 2, 'o': 1, 'p': 4, 'q': 10, 'r': 1,
+	's': 1, 't': 1, 'u': 2, 'v': 5, 'w': 4, 'x': 8, 'y': 3, 'z': 10,
+}
+
+// Named letter value tables selectable with the -letter-values flag.
+var letterValueTables = map[string]LetterValues{
+	"scrabble": scrabbleValues,
+	"wwf":      wordsWithFriendsValues,
+}
+
+// Returns the named letter value table, or loads a custom one from the file
+// at name. Custom files have one letter and its value per line, e.g. "q 10".
+func loadLetterValues(name string) (LetterValues, error) {
+	if values, ok := letterValueTables[name]; ok {
+		return values, nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := make(LetterValues)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		letter, size := utf8.DecodeRuneInString(strings.ToLower(fields[0]))
+		if len(fields) != 2 || size != len(fields[0]) {
+			return nil, fmt.Errorf("%s:%d: expected a letter and its value", name, line)
+		}
+		value, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", name, line, err)
+		}
+		values[letter] = value
+	}
+	return values, scanner.Err()
+}
+
+// Returns the sum of the values of the letters in word.
+func (v LetterValues) Score(word string) int {
+	score := 0
+	for _, r := range word {
+		score += v[r]
+	}
+	return score
+}
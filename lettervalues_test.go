@@ -0,0 +1,84 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLetterValuesScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   LetterValues
+		word     string
+		expected int
+	}{
+		{
+			name:     "scrabble",
+			values:   scrabbleValues,
+			word:     "quiz",
+			expected: 22,
+		},
+		{
+			name:     "words with friends",
+			values:   wordsWithFriendsValues,
+			word:     "quiz",
+			expected: 23,
+		},
+		{
+			name:     "unknown letters are worth nothing",
+			values:   scrabbleValues,
+			word:     "a-b",
+			expected: 4,
+		},
+		{
+			name:     "empty word",
+			values:   scrabbleValues,
+			word:     "",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.values.Score(tt.word); result != tt.expected {
+				t.Errorf("Score(%q) = %d, want %d", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadLetterValues(t *testing.T) {
+	values, err := loadLetterValues("wwf")
+	if err != nil {
+		t.Fatalf("loadLetterValues(%q) returned error: %v", "wwf", err)
+	}
+	if values['j'] != 10 {
+		t.Errorf("wwf value of j = %d, want 10", values['j'])
+	}
+
+	path := filepath.Join(t.TempDir(), "values.txt")
+	if err := os.WriteFile(path, []byte("A 2\n\nb 5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	values, err = loadLetterValues(path)
+	if err != nil {
+		t.Fatalf("loadLetterValues(%q) returned error: %v", path, err)
+	}
+	if got := values.Score("abc"); got != 7 {
+		t.Errorf("custom Score(%q) = %d, want 7", "abc", got)
+	}
+
+	for _, content := range []string{"ab 2\n", "a\n", "a two\n"} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadLetterValues(path); err == nil {
+			t.Errorf("loadLetterValues with content %q returned no error", content)
+		}
+	}
+
+	if _, err := loadLetterValues(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("loadLetterValues of a missing file returned no error")
+	}
+}
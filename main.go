@@ -1,10 +1,15 @@
 package main
 
 import (
+	_ "embed"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"time"
 )
@@ -15,41 +20,107 @@ func check(err error) {
 	}
 }
 
+const (
+	StatusOK = "ok"
+	// StatusUnsupported marks a result where at least one condition could not be parsed.
+	StatusUnsupported = "unsupported"
+)
+
 type Result struct {
-	Name       string   `json:"name"`
-	Condition1 string   `json:"condition_1"`
-	Condition2 string   `json:"condition_2"`
-	Words      []string `json:"words"`
+	Name       string `json:"name"`
+	Condition1 string `json:"condition_1"`
+	Condition2 string `json:"condition_2"`
+	Status     string `json:"status"`
+	Words      []Word `json:"words"`
 }
 
-func getSolutions(row_predicates, col_predicates []Predicate) []Result {
+// The default dictionary, used unless -dict names others.
+//
+//go:embed words.txt
+var embeddedWords string
+
+// The name of the embedded word list.
+const embeddedListName = "sowpods"
+
+func loadWords(path string) []string {
 	fmt.Println("Loading dictionary...")
-	raw_words, err := os.ReadFile("words.txt")
+	raw_words, err := os.ReadFile(path)
 	check(err)
-	words := strings.Split(strings.ReplaceAll(string(raw_words), "\r\n", "\n"), "\n")
+	return splitWords(string(raw_words))
+}
 
-	fmt.Println("Calculating results...")
-	results := make([]Result, 0, len(col_predicates)*len(row_predicates))
-	for _, col := range col_predicates {
-		for _, row := range row_predicates {
-			var filtered []string
-			for _, w := range words {
-				if col.Func(w) && row.Func(w) {
-					filtered = append(filtered, w)
-				}
-			}
+// Splits the text of a dictionary file into normalized words.
+func splitWords(text string) []string {
+	return normalizeWords(strings.Split(text, "\n"))
+}
 
-			resultName := fmt.Sprintf("%s & %s", col.Name, row.Name)
-			results = append(results, Result{
-				Name:       resultName,
-				Condition1: col.Name,
-				Condition2: row.Name,
-				Words:      filtered,
-			})
-		}
+// Loads the dictionary at path, or the embedded one if path is empty.
+func openDictionary(path string) *Dictionary {
+	if path == "" {
+		fmt.Println("Loading embedded dictionary...")
+		return newDictionary(splitWords(embeddedWords))
+	}
+	return loadDictionary(path, binaryDictionaryPath(path))
+}
+
+// Sets the word rarities of d from the frequency file at path, if any.
+func loadFrequenciesInto(d *Dictionary, path string) {
+	if path == "" {
+		return
+	}
+	freqs, err := loadFrequencies(path)
+	check(err)
+	d.setFrequencies(freqs)
+}
+
+// A word list given with -dict.
+type wordListFlag struct {
+	Name string
+	Path string
+}
+
+// wordListFlags collects the -dict flags, each of the form "name=path" or
+// "path". Lists given by path alone are named after the file.
+type wordListFlags []wordListFlag
+
+func (f *wordListFlags) String() string {
+	var lists []string
+	for _, l := range *f {
+		lists = append(lists, l.Name+"="+l.Path)
 	}
+	return strings.Join(lists, ", ")
+}
 
-	return results
+func (f *wordListFlags) Set(value string) error {
+	name, path, named := strings.Cut(value, "=")
+	if !named {
+		path = value
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if name == "" || path == "" {
+		return fmt.Errorf("invalid word list %q, want name=path or path", value)
+	}
+	*f = append(*f, wordListFlag{Name: name, Path: path})
+	return nil
+}
+
+// Loads and merges the given word lists, or the embedded one if there are none.
+func openWordLists(lists wordListFlags) *Dictionary {
+	if len(lists) == 0 {
+		lists = wordListFlags{{Name: embeddedListName}}
+	}
+	names := make([]string, len(lists))
+	dicts := make([]*Dictionary, len(lists))
+	for i, l := range lists {
+		names[i] = l.Name
+		dicts[i] = openDictionary(l.Path)
+	}
+	return mergeDictionaries(names, dicts)
+}
+
+func getSolutions(ctx *Context, row_predicates, col_predicates []Predicate) []Result {
+	fmt.Println("Calculating results...")
+	return solve(ctx, row_predicates, col_predicates, runtime.GOMAXPROCS(0))
 }
 
 const START_GAME_NUMBER = 442
@@ -64,7 +135,44 @@ func getGameNumber() int {
 	return START_GAME_NUMBER + diffDays
 }
 
-func getPredicates() ([]Predicate, []Predicate) {
+// Parses a clue into a Predicate, preferring its code and falling back to its
+// text. Unparseable clues are reported and returned with Err set so that their
+// cells can be marked as unsupported.
+func newPredicate(text, code string) Predicate {
+	if code != "" {
+		n, err := parseCode(code)
+		if err == nil {
+			return Predicate{Name: text, Code: code, Node: n}
+		}
+		fmt.Println("Falling back to clue text:", err)
+	}
+
+	n, err := parseClue(text)
+	if err != nil {
+		fmt.Println("Unsupported predicate:", err)
+		return Predicate{Name: text, Code: code, Err: err}
+	}
+	return Predicate{
+		Name: text,
+		Code: code,
+		Node: n,
+	}
+}
+
+// A game as returned by the WordGrid API.
+type gameData struct {
+	Rows    []gameClue `json:"rows"`
+	Columns []gameClue `json:"columns"`
+}
+
+type gameClue struct {
+	Text string `json:"text"`
+	Code string `json:"code"`
+}
+
+// Fetches today's game and parses its clues. If savePath is not empty, the
+// raw response is also written there, e.g. to capture a test fixture.
+func getPredicates(savePath string) ([]Predicate, []Predicate) {
 	resp, err := http.Get("https://api.clevergoat.com/wordgrid/game/" + fmt.Sprint(getGameNumber()))
 	check(err)
 	defer resp.Body.Close()
@@ -73,59 +181,131 @@ func getPredicates() ([]Predicate, []Predicate) {
 		panic("Failed to get predicates")
 	}
 
-	var data struct {
-		Rows []struct {
-			Text string `json:"text"`
-			Code string `json:"code"`
-		} `json:"rows"`
-		Columns []struct {
-			Text string `json:"text"`
-			Code string `json:"code"`
-		} `json:"columns"`
+	body, err := io.ReadAll(resp.Body)
+	check(err)
+	if savePath != "" {
+		check(os.WriteFile(savePath, body, 0644))
 	}
-	err = json.NewDecoder(resp.Body).Decode(&data)
+
+	var data gameData
+	err = json.Unmarshal(body, &data)
 	check(err)
+	return gamePredicates(data)
+}
 
+// Parses the clues of a game into row and column predicates.
+func gamePredicates(data gameData) ([]Predicate, []Predicate) {
 	rowPredicates := make([]Predicate, len(data.Rows))
 	for i, row := range data.Rows {
-		rowPredicates[i] = Predicate{
-			Name: row.Text,
-			Func: parsePredicate(row.Text),
-		}
+		rowPredicates[i] = newPredicate(row.Text, row.Code)
 	}
 
 	colPredicates := make([]Predicate, len(data.Columns))
 	for i, col := range data.Columns {
-		colPredicates[i] = Predicate{
-			Name: col.Text,
-			Func: parsePredicate(col.Text),
-		}
+		colPredicates[i] = newPredicate(col.Text, col.Code)
 	}
 
 	return rowPredicates, colPredicates
 }
 
 func main() {
-	rowPredicates, colPredicates := getPredicates()
-	results := getSolutions(rowPredicates, colPredicates)
+	crossCheck := flag.Bool("crosscheck", false, "report clues whose code and text select different words")
+	query := flag.String("query", "", "print the dictionary words matching a clue, e.g. \"starts with a or ends with e\", and exit")
+	listClues := flag.Bool("clues", false, "list the supported clue families and exit")
+	letterValuesName := flag.String("letter-values", "scrabble", "letter values for score clues: scrabble, wwf or the path of a file of \"letter value\" lines")
+	var wordLists wordListFlags
+	flag.Var(&wordLists, "dict", "word list of one word per line to use instead of the embedded one, as name=path or path; may be repeated to tag words with the lists containing them. A binary dictionary compiled from a list with compile-dict is used while up to date")
+	frequenciesPath := flag.String("frequencies", "", "path of a file of \"word count\" lines, used to rank result words rarest first")
+	outPath := flag.String("out", "./web/src/results.json", "path of the results file to write")
+	saveGamePath := flag.String("save-game", "", "also write the raw API response for today's game to this path, e.g. testdata/games/460.json")
+	flag.Parse()
+
+	letterValues, err := loadLetterValues(*letterValuesName)
+	check(err)
+
+	if flag.Arg(0) == "compile-dict" {
+		var paths [][2]string
+		switch {
+		case flag.NArg() > 1:
+			binPath := binaryDictionaryPath(flag.Arg(1))
+			if flag.NArg() > 2 {
+				binPath = flag.Arg(2)
+			}
+			paths = append(paths, [2]string{flag.Arg(1), binPath})
+		case len(wordLists) > 0:
+			for _, l := range wordLists {
+				paths = append(paths, [2]string{l.Path, binaryDictionaryPath(l.Path)})
+			}
+		default:
+			fmt.Fprintln(os.Stderr, "usage: compile-dict words.txt [words.bin], or -dict path compile-dict")
+			os.Exit(2)
+		}
+		for _, p := range paths {
+			check(compileDictionary(p[0], p[1]))
+			fmt.Println("Dictionary written to", p[1])
+		}
+		return
+	}
+
+	if *listClues {
+		for _, c := range registeredClues() {
+			fmt.Printf("%s\n\t%s\n\te.g. %s\n", c.Pattern, c.Description, strings.Join(c.Examples, "; "))
+		}
+		return
+	}
+
+	if *query != "" {
+		n, err := parseClue(*query)
+		check(err)
+		fmt.Println("Query:", n)
+		ctx := newDictionaryContext(openWordLists(wordLists))
+		ctx.LetterValues = letterValues
+		loadFrequenciesInto(ctx.Dictionary, *frequenciesPath)
+		for _, w := range ctx.taggedWordsIn(ctx.Select(n)) {
+			line := w.Word
+			if len(ctx.Lists) > 1 {
+				line += "\t" + strings.Join(w.Lists, ", ")
+			}
+			if w.Rarity != nil {
+				line += fmt.Sprintf("\t%.3f", *w.Rarity)
+			}
+			fmt.Println(line)
+		}
+		return
+	}
+
+	rowPredicates, colPredicates := getPredicates(*saveGamePath)
+	ctx := newDictionaryContext(openWordLists(wordLists))
+	ctx.LetterValues = letterValues
+	loadFrequenciesInto(ctx.Dictionary, *frequenciesPath)
+
+	if *crossCheck {
+		for _, d := range crossCheckPredicates(ctx, append(rowPredicates, colPredicates...)) {
+			fmt.Printf("Code %q disagrees with %q on %d words, e.g. %s\n", d.Code, d.Name, d.Count, strings.Join(d.Examples, ", "))
+		}
+	}
+
+	results := getSolutions(ctx, rowPredicates, colPredicates)
 
 	// Add timestamp to results data
 	type ResultsData struct {
 		GameNumber int       `json:"game_number"`
 		Timestamp  time.Time `json:"timestamp"`
+		Lists      []string  `json:"lists"`
 		Results    []Result  `json:"results"`
 	}
 
 	resultsData := ResultsData{
 		GameNumber: getGameNumber(),
 		Timestamp:  time.Now().UTC(),
+		Lists:      ctx.Lists,
 		Results:    results,
 	}
 
 	// Write results to a JSON file
 	jsonData, err := json.MarshalIndent(resultsData, "", "  ")
 	check(err)
-	err = os.WriteFile("./web/src/results.json", jsonData, 0644)
+	err = os.WriteFile(*outPath, jsonData, 0644)
 	check(err)
-	fmt.Println("Results written to ./web/src/results.json")
+	fmt.Println("Results written to", *outPath)
 }
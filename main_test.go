@@ -0,0 +1,25 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWordListFlags(t *testing.T) {
+	var lists wordListFlags
+	for _, value := range []string{"twl=/tmp/twl06.txt", "lists/enable.txt"} {
+		if err := lists.Set(value); err != nil {
+			t.Fatalf("Set(%q) returned error: %v", value, err)
+		}
+	}
+	want := wordListFlags{{Name: "twl", Path: "/tmp/twl06.txt"}, {Name: "enable", Path: "lists/enable.txt"}}
+	if !reflect.DeepEqual(lists, want) {
+		t.Errorf("lists = %+v, want %+v", lists, want)
+	}
+
+	for _, value := range []string{"", "name=", "=path"} {
+		if err := lists.Set(value); err == nil {
+			t.Errorf("Set(%q) returned no error", value)
+		}
+	}
+}
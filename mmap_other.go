@@ -0,0 +1,15 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// Reads the file at path into memory, on platforms without mmap. The data
+// must not be used after calling unmap.
+func mapFile(path string) (data []byte, unmap func() error, err error) {
+	data, err = os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalizes a dictionary word or clue argument so that words that look the
+// same compare the same: surrounding space is trimmed, case is folded and
+// combining marks are composed with the letters they follow.
+func normalizeWord(s string) string {
+	return norm.NFC.String(foldCase(strings.TrimSpace(s)))
+}
+
+// Normalizes each of words, dropping those that are empty once normalized.
+func normalizeWords(words []string) []string {
+	normalized := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = normalizeWord(w); w != "" {
+			normalized = append(normalized, w)
+		}
+	}
+	return normalized
+}
+
+// Folds the case of s, mapping e.g. "Σ", "σ" and "ς" all to "σ".
+func foldCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		return unicode.ToLower(unicode.ToUpper(r))
+	}, s)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected string
+	}{
+		{
+			name:     "ascii",
+			word:     "hello",
+			expected: "hello",
+		},
+		{
+			name:     "mixed case",
+			word:     "HeLLo",
+			expected: "hello",
+		},
+		{
+			name:     "surrounding space",
+			word:     " hello\r",
+			expected: "hello",
+		},
+		{
+			name:     "precomposed",
+			word:     "Caf\u00e9",
+			expected: "caf\u00e9",
+		},
+		{
+			name:     "combining mark",
+			word:     "CAFE\u0301",
+			expected: "caf\u00e9",
+		},
+		{
+			name:     "two combining marks",
+			word:     "A\u0323\u0302",
+			expected: "\u1ead",
+		},
+		{
+			name:     "combining marks out of canonical order",
+			word:     "a\u0302\u0323",
+			expected: "\u1ead",
+		},
+		{
+			name:     "greek",
+			word:     "\u0391\u0301",
+			expected: "\u03ac",
+		},
+		{
+			name:     "cyrillic",
+			word:     "\u0418\u0306",
+			expected: "\u0439",
+		},
+		{
+			name:     "hangul jamo",
+			word:     "\u1100\u1161\u11a8",
+			expected: "\uac01",
+		},
+		{
+			name:     "final sigma",
+			word:     "ΟΔΟΣ",
+			expected: "οδοσ",
+		},
+		{
+			name:     "lone combining mark",
+			word:     "\u0301",
+			expected: "\u0301",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := normalizeWord(tt.word); result != tt.expected {
+				t.Errorf("normalizeWord(%q) = %q, want %q", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeWords(t *testing.T) {
+	got := normalizeWords([]string{"Apple\r", "", "  ", "E\u0301COLE", "οδος"})
+	want := []string{"apple", "\u00e9cole", "οδοσ"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeWords() = %q, want %q", got, want)
+	}
+}
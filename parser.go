@@ -0,0 +1,367 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenPunct
+	// A regular expression written between slashes, e.g. /^c.t$/.
+	tokenRegexp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// Characters that always form a token of their own.
+const punctuation = ",&()"
+
+// Splits a clue into normalized word and punctuation tokens. Curly apostrophes
+// are replaced with straight ones so that "l’s" and "l's" tokenize the same.
+// Text between slashes is kept verbatim as a single regular expression token,
+// in which "\/" stands for a slash. Letter classes between square brackets,
+// like "[a, e]", are kept within their word token, punctuation included.
+func tokenize(clue string) []token {
+	clue = strings.ReplaceAll(clue, "’", "'")
+
+	var tokens []token
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, token{kind: tokenWord, text: normalizeWord(word.String())})
+			word.Reset()
+		}
+	}
+
+	inRegexp := false
+	inClass := false
+	escaped := false
+	for _, r := range clue {
+		switch {
+		case inClass:
+			word.WriteRune(r)
+			inClass = r != ']'
+		case r == '[':
+			word.WriteRune(r)
+			inClass = true
+		case inRegexp && escaped:
+			if r != '/' {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(r)
+			escaped = false
+		case inRegexp && r == '\\':
+			escaped = true
+		case inRegexp && r == '/':
+			tokens = append(tokens, token{kind: tokenRegexp, text: word.String()})
+			word.Reset()
+			inRegexp = false
+		case inRegexp:
+			word.WriteRune(r)
+		case r == '/':
+			flush()
+			inRegexp = true
+		case unicode.IsSpace(r):
+			flush()
+		case strings.ContainsRune(punctuation, r):
+			flush()
+			tokens = append(tokens, token{kind: tokenPunct, text: string(r)})
+		default:
+			word.WriteRune(r)
+		}
+	}
+	if inRegexp {
+		if escaped {
+			word.WriteRune('\\')
+		}
+		tokens = append(tokens, token{kind: tokenRegexp, text: word.String()})
+	} else {
+		flush()
+	}
+
+	return tokens
+}
+
+// Spelled-out numbers below twenty, indexed by value.
+var smallNumberWords = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+	"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+}
+
+// Spelled-out multiples of ten from twenty to ninety, indexed by value / 10.
+var tensWords = []string{
+	"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety",
+}
+
+// Parses a number written in digits, like "12", or spelled out, like "twelve"
+// or "twenty-one". Spelled-out numbers go up to ninety-nine.
+func parseNumber(s string) (int, error) {
+	if s != "" && s[0] >= '0' && s[0] <= '9' {
+		return strconv.Atoi(s)
+	}
+	if n, ok := parseNumberWord(s); ok {
+		return n, nil
+	}
+	return 0, fmt.Errorf("%q is not a number", s)
+}
+
+func parseNumberWord(s string) (int, bool) {
+	if i := slices.Index(smallNumberWords, s); i >= 0 {
+		return i, true
+	}
+	tens, units, hyphenated := strings.Cut(s, "-")
+	i := slices.Index(tensWords, tens)
+	if i < 2 {
+		return 0, false
+	}
+	if !hyphenated {
+		return i * 10, true
+	}
+	j := slices.Index(smallNumberWords, units)
+	if j < 1 || j > 9 {
+		return 0, false
+	}
+	return i*10 + j, true
+}
+
+// Returns the spelled-out form of n, or "" if there is none.
+func numberWordOf(n int) string {
+	switch {
+	case n < 0 || n >= 100:
+		return ""
+	case n < len(smallNumberWords):
+		return smallNumberWords[n]
+	case n%10 == 0:
+		return tensWords[n/10]
+	default:
+		return tensWords[n/10] + "-" + smallNumberWords[n%10]
+	}
+}
+
+var ordinalWords = []string{
+	"first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth", "tenth",
+	"eleventh", "twelfth", "thirteenth", "fourteenth", "fifteenth", "sixteenth", "seventeenth",
+	"eighteenth", "nineteenth", "twentieth",
+}
+
+// Parses an ordinal like "second" or "2nd".
+func parseOrdinal(s string) (int, bool) {
+	for i, w := range ordinalWords {
+		if s == w {
+			return i + 1, true
+		}
+	}
+	if len(s) < 3 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-2])
+	if err != nil || n < 1 || ordinalSuffix(n) != s[len(s)-2:] {
+		return 0, false
+	}
+	return n, true
+}
+
+// Formats n as a spelled-out ordinal where possible, e.g. "second", or as
+// digits with a suffix otherwise, e.g. "21st".
+func formatOrdinal(n int) string {
+	if n >= 1 && n <= len(ordinalWords) {
+		return ordinalWords[n-1]
+	}
+	return strconv.Itoa(n) + ordinalSuffix(n)
+}
+
+func ordinalSuffix(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return "th"
+	case n%10 == 1:
+		return "st"
+	case n%10 == 2:
+		return "nd"
+	case n%10 == 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+type parser struct {
+	clue   string
+	tokens []token
+	pos    int
+}
+
+// Parses a clue like "Starts with mi" into a Node.
+// Returns an *UnknownClueError, *MalformedNumberError, *UnknownNumberWordError,
+// *InvalidPositionError or *InvalidPatternError if the clue cannot be parsed.
+//
+// Clues may be combined with "not", "and" and "or", in decreasing order of
+// precedence, and grouped with parentheses:
+//
+//	expr  = and { "or" and }
+//	and   = unary { "and" unary }
+//	unary = "not" unary | "(" expr ")" | atom
+func parseClue(clue string) (Node, error) {
+	p := &parser{clue: clue, tokens: tokenize(clue)}
+	n, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, p.unknown()
+	}
+	return n, nil
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// Returns the text of an upcoming word or punctuation token, or "" if there
+// is none.
+func (p *parser) peek(offset int) string {
+	if p.pos+offset >= len(p.tokens) || p.tokens[p.pos+offset].kind == tokenRegexp {
+		return ""
+	}
+	return p.tokens[p.pos+offset].text
+}
+
+// Consumes the next token if its text is s.
+func (p *parser) accept(s string) bool {
+	if p.peek(0) == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// Consumes the given sequence of tokens or fails.
+func (p *parser) expect(s ...string) error {
+	for _, text := range s {
+		if !p.accept(text) {
+			return p.unknown()
+		}
+	}
+	return nil
+}
+
+func (p *parser) unknown() error {
+	return &UnknownClueError{Clue: p.clue}
+}
+
+func (p *parser) parseExpr() (Node, error) {
+	n, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek(0) != "or" {
+		return n, nil
+	}
+
+	nodes := []Node{n}
+	for p.accept("or") {
+		n, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return Or{Nodes: nodes}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	n, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek(0) != "and" {
+		return n, nil
+	}
+
+	nodes := []Node{n}
+	for p.accept("and") {
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return And{Nodes: nodes}, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	switch {
+	case p.accept("not"):
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Node: n}, nil
+	case p.accept("("):
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return n, nil
+	default:
+		return p.parseAtom()
+	}
+}
+
+// Parses a single clue by matching the registered clue families against the
+// upcoming tokens. The family matching the most tokens wins, so that e.g.
+// "contains the letter {text}" is preferred over "contains {list}" regardless
+// of registration order. Among equally long matches, families whose arguments
+// fail to parse, like "{ordinal} letter is {text}" for "Last letter is e",
+// give way to the others; if none remain, the first error is returned.
+func (p *parser) parseAtom() (Node, error) {
+	type candidate struct {
+		clue *Clue
+		end  int
+		raw  []any
+	}
+	var candidates []candidate
+	for _, c := range registeredClues() {
+		if end, raw, ok := c.match(p); ok {
+			candidates = append(candidates, candidate{c, end, raw})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, p.unknown()
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return b.end - a.end
+	})
+
+	var firstErr error
+	for _, c := range candidates {
+		if c.end < candidates[0].end {
+			break
+		}
+		args, err := c.clue.parseArgs(p.clue, c.raw)
+		if err == nil {
+			var n Node
+			n, err = c.clue.build(args)
+			if err == nil {
+				p.pos = c.end
+				return n, nil
+			}
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
@@ -2,13 +2,79 @@ package main
 
 import (
 	"fmt"
-	"strconv"
+	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf8"
 )
 
 type Predicate struct {
 	Name string
-	Func func(word string) bool
+	// Code is the machine-readable form of Name, if one is available.
+	Code string
+	// Node is the parsed clue, selected from the dictionary by Context.Select.
+	Node Node
+	// Err is set when neither Code nor Name could be parsed; Node is nil in that case.
+	Err error
+}
+
+// UnknownClueError is returned when a clue does not match any known phrasing.
+type UnknownClueError struct {
+	Clue string
+}
+
+func (e *UnknownClueError) Error() string {
+	return fmt.Sprintf("cannot parse predicate %q", e.Clue)
+}
+
+// MalformedNumberError is returned when a clue contains a number that cannot be parsed.
+type MalformedNumberError struct {
+	Clue   string
+	Number string
+	Err    error
+}
+
+func (e *MalformedNumberError) Error() string {
+	return fmt.Sprintf("cannot parse number %q in predicate %q: %v", e.Number, e.Clue, e.Err)
+}
+
+func (e *MalformedNumberError) Unwrap() error {
+	return e.Err
+}
+
+// UnknownNumberWordError is returned when a clue spells out a number that is not recognized.
+type UnknownNumberWordError struct {
+	Clue string
+	Word string
+}
+
+func (e *UnknownNumberWordError) Error() string {
+	return fmt.Sprintf("unknown number word %q in predicate %q", e.Word, e.Clue)
+}
+
+// InvalidPositionError is returned when a clue refers to a letter position below 1.
+type InvalidPositionError struct {
+	Clue     string
+	Position int
+}
+
+func (e *InvalidPositionError) Error() string {
+	return fmt.Sprintf("invalid letter position %d in predicate %q: positions start at 1", e.Position, e.Clue)
+}
+
+// InvalidPatternError is returned when a clue contains a pattern or regular expression that cannot be compiled.
+type InvalidPatternError struct {
+	Clue    string
+	Pattern string
+	Err     error
+}
+
+func (e *InvalidPatternError) Error() string {
+	return fmt.Sprintf("invalid pattern %q in predicate %q: %v", e.Pattern, e.Clue, e.Err)
+}
+
+func (e *InvalidPatternError) Unwrap() error {
+	return e.Err
 }
 
 // Returns a function that checks if a word starts with the given prefix.
@@ -59,10 +125,125 @@ func wordDoesNotContain(substrings []string) func(word string) bool {
 // Returns a function that checks if a word has any double letters.
 func wordHasDoubleLetter() func(word string) bool {
 	return func(word string) bool {
-		for i := range len(word) - 1 {
-			if word[i] == word[i+1] {
+		var prev rune
+		for i, r := range word {
+			if i > 0 && r == prev {
 				return true
 			}
+			prev = r
+		}
+		return false
+	}
+}
+
+// Returns a function that checks if a word has three identical letters in a row.
+func wordHasTripleLetter() func(word string) bool {
+	return func(word string) bool {
+		letters := []rune(word)
+		for i := range len(letters) - 2 {
+			if letters[i] == letters[i+1] && letters[i] == letters[i+2] {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Returns a function that checks if a word has double letters of at least count different letters.
+func wordHasDoubleLetters(count int) func(word string) bool {
+	return func(word string) bool {
+		doubled := make(map[rune]bool)
+		var prev rune
+		for i, r := range word {
+			if i > 0 && r == prev {
+				doubled[r] = true
+			}
+			prev = r
+		}
+		return len(doubled) >= count
+	}
+}
+
+// Returns a function that checks if no letter appears more than once in a word.
+func wordHasNoRepeatedLetters() func(word string) bool {
+	return func(word string) bool {
+		seen := make(map[rune]bool)
+		for _, r := range word {
+			if seen[r] {
+				return false
+			}
+			seen[r] = true
+		}
+		return true
+	}
+}
+
+// Returns a function that checks if a word is made of exactly count distinct letters.
+func wordHasDistinctLetters(count int) func(word string) bool {
+	return func(word string) bool {
+		seen := make(map[rune]bool)
+		for _, r := range word {
+			seen[r] = true
+		}
+		return len(seen) == count
+	}
+}
+
+// Returns a function that checks if the letters of a word are in alphabetical order.
+// Repeated letters are allowed.
+func wordLettersInAlphabeticalOrder() func(word string) bool {
+	return func(word string) bool {
+		var prev rune
+		for i, r := range word {
+			if i > 0 && prev > r {
+				return false
+			}
+			prev = r
+		}
+		return true
+	}
+}
+
+// Returns a function that checks if the letters of a word are in reverse alphabetical order.
+// Repeated letters are allowed.
+func wordLettersInReverseAlphabeticalOrder() func(word string) bool {
+	return func(word string) bool {
+		var prev rune
+		for i, r := range word {
+			if i > 0 && prev < r {
+				return false
+			}
+			prev = r
+		}
+		return true
+	}
+}
+
+// Returns a function that checks if a word's first letter comes after its last letter in the alphabet.
+func wordFirstLetterAfterLastLetter() func(word string) bool {
+	return func(word string) bool {
+		first, _ := utf8.DecodeRuneInString(word)
+		last, _ := utf8.DecodeLastRuneInString(word)
+		return len(word) > 0 && first > last
+	}
+}
+
+// Returns a function that checks if a word contains a run of the given number of
+// consecutive alphabet letters, such as "abc" or "rst".
+func wordHasAlphabeticalRun(length int) func(word string) bool {
+	return func(word string) bool {
+		run := 0
+		var prev rune
+		for _, r := range word {
+			if run > 0 && prev+1 == r {
+				run++
+			} else {
+				run = 1
+			}
+			if run >= length {
+				return true
+			}
+			prev = r
 		}
 		return false
 	}
@@ -71,27 +252,27 @@ func wordHasDoubleLetter() func(word string) bool {
 // Returns a function that checks if a word's length is greater than the given length.
 func wordLengthGreaterThan(length int) func(word string) bool {
 	return func(word string) bool {
-		return len(word) > length
+		return utf8.RuneCountInString(word) > length
 	}
 }
 
 // Returns a function that checks if a word's length is less than the given length.
 func wordLengthLessThan(length int) func(word string) bool {
 	return func(word string) bool {
-		return len(word) < length
+		return utf8.RuneCountInString(word) < length
 	}
 }
 
 // Returns a function that checks if a word's length is equal to the given length.
 func wordLengthEqualsTo(length int) func(word string) bool {
 	return func(word string) bool {
-		return len(word) == length
+		return utf8.RuneCountInString(word) == length
 	}
 }
 
 func wordLengthBetween(low, high int) func(word string) bool {
 	return func(word string) bool {
-		wordLen := len(word)
+		wordLen := utf8.RuneCountInString(word)
 		return wordLen >= low && wordLen <= high
 	}
 }
@@ -103,100 +284,315 @@ func wordContainsMoreThanOne(letter string) func(word string) bool {
 	}
 }
 
-// Parses a string like "Starts with mi" and returns a corresponding predicate.
-func parsePredicate(predicate string) func(word string) bool {
-	predicate = strings.ToLower(predicate)
-
-	switch {
-	// Starts with X - The word must start with X.
-	case strings.HasPrefix(predicate, "starts with"):
-		return wordStartsWith(strings.TrimPrefix(predicate, "starts with "))
-	// Ends with X - The word must end with X.
-	case strings.HasPrefix(predicate, "ends with"):
-		return wordEndsWith(strings.TrimPrefix(predicate, "ends with "))
-	// Contains the letter X
-	case strings.HasPrefix(predicate, "contains the letter"):
-		letter := strings.TrimSpace(strings.TrimPrefix(predicate, "contains the letter "))
-		return wordContains([]string{letter})
-	// Contains X, Y, Z - Must include each letter anywhere in the word.
-	// Contains XY - Must contain the exact sequence.
-	case strings.HasPrefix(predicate, "contains"):
-		chars := strings.Split(strings.TrimPrefix(predicate, "contains "), ",")
-		for i, c := range chars {
-			chars[i] = strings.TrimSpace(c)
-		}
-		// fmt.Println("chars:", chars)
-		return wordContains(chars)
-	// Does not contain X, Y, Z
-	case strings.HasPrefix(predicate, "does not contain"):
-		chars := strings.Split(strings.TrimPrefix(predicate, "does not contain "), ",")
-		for i, c := range chars {
-			chars[i] = strings.TrimSpace(c)
-		}
-		return wordDoesNotContain(chars)
-	// Between X and Y letters
-	case strings.HasPrefix(predicate, "between"):
-		low := 0
-		high := 0
-		fmt.Sscanf(predicate, "between %d and %d letters", &low, &high)
-		return wordLengthBetween(low, high)
-	// Multiple letter X’s - More than one occurrence of X.
-	case strings.HasPrefix(predicate, "multiple letter"):
-		rest := strings.TrimPrefix(predicate, "multiple letter ")
-		letter := strings.TrimSuffix(rest, "'s")
-		return wordContainsMoreThanOne(letter)
-	// Multiple X’s - More than one occurrence of X.
-	case strings.HasPrefix(predicate, "multiple"):
-		rest := strings.TrimPrefix(predicate, "multiple ")
-		letter := strings.TrimSuffix(rest, "'s")
-		return wordContainsMoreThanOne(letter)
-	// Double letter - Includes two identical letters in a row.
-	case predicate == "double letter":
-		return wordHasDoubleLetter()
-	// Starts & ends with X
-	case strings.HasPrefix(predicate, "starts & ends with"):
-		s := strings.TrimPrefix(predicate, "starts & ends with ")
-		return wordStartsAndEndsWith(s, s)
-	// X letters or fewer
-	case strings.HasSuffix(predicate, "letters or fewer"):
-		num, err := strconv.Atoi(strings.TrimSuffix(predicate, " letters or fewer"))
-		check(err)
-		return wordLengthLessThan(num + 1)
-	// X letters or more
-	case strings.HasSuffix(predicate, "letters or more"):
-		num, err := strconv.Atoi(strings.TrimSuffix(predicate, " letters or more"))
-		check(err)
-		return wordLengthGreaterThan(num - 1)
-	// X letter word - The word must have that many letters.
-	case strings.HasSuffix(predicate, "letter word"):
-		num := strings.TrimSuffix(predicate, " letter word")
-		switch num {
-		case "two":
-			return wordLengthEqualsTo(2)
-		case "three":
-			return wordLengthEqualsTo(3)
-		case "four":
-			return wordLengthEqualsTo(4)
-		case "five":
-			return wordLengthEqualsTo(5)
-		case "six":
-			return wordLengthEqualsTo(6)
-		case "seven":
-			return wordLengthEqualsTo(7)
-		case "eight":
-			return wordLengthEqualsTo(8)
-		case "nine":
-			return wordLengthEqualsTo(9)
-		case "ten":
-			return wordLengthEqualsTo(10)
+// Returns a function that checks if a word contains exactly count occurrences of a given letter.
+func wordContainsExactly(letter string, count int) func(word string) bool {
+	return func(word string) bool {
+		return strings.Count(word, letter) == count
+	}
+}
+
+// Returns word with its letters in reverse order.
+func reverseWord(word string) string {
+	letters := []rune(word)
+	slices.Reverse(letters)
+	return string(letters)
+}
+
+// Returns a function that checks if a word reads the same backwards.
+func wordIsPalindrome() func(word string) bool {
+	return func(word string) bool {
+		return word == reverseWord(word)
+	}
+}
+
+// Returns a function that checks if a word starts and ends with the same letter.
+func wordStartsAndEndsWithSameLetter() func(word string) bool {
+	return func(word string) bool {
+		first, _ := utf8.DecodeRuneInString(word)
+		last, _ := utf8.DecodeLastRuneInString(word)
+		return len(word) > 0 && first == last
+	}
+}
+
+// Returns a function that checks if a word reversed is a different word in the dictionary.
+func wordReversedIsWord(ctx *Context) func(word string) bool {
+	return func(word string) bool {
+		reversed := reverseWord(word)
+		return reversed != word && ctx.IsWord(reversed)
+	}
+}
+
+// Returns a function that checks if a word contains a different dictionary word of at least minLength letters.
+func wordContainsWord(ctx *Context, minLength int) func(word string) bool {
+	return func(word string) bool {
+		offsets := letterOffsets(word)
+		n := len(offsets) - 1
+		for i := range n {
+			for j := i + minLength; j <= n; j++ {
+				if j-i < n && ctx.IsWord(word[offsets[i]:offsets[j]]) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// Returns a function that checks if a word begins with a shorter dictionary word of at least minLength letters.
+func wordStartsWithWord(ctx *Context, minLength int) func(word string) bool {
+	return func(word string) bool {
+		offsets := letterOffsets(word)
+		for j := minLength; j < len(offsets)-1; j++ {
+			if ctx.IsWord(word[:offsets[j]]) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Returns a function that checks if a word ends with a shorter dictionary word of at least minLength letters.
+func wordEndsWithWord(ctx *Context, minLength int) func(word string) bool {
+	return func(word string) bool {
+		offsets := letterOffsets(word)
+		for i := 1; i <= len(offsets)-1-minLength; i++ {
+			if ctx.IsWord(word[offsets[i]:]) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Returns the byte offset of each letter of word, followed by len(word).
+func letterOffsets(word string) []int {
+	offsets := make([]int, 0, len(word)+1)
+	for i := range word {
+		offsets = append(offsets, i)
+	}
+	return append(offsets, len(word))
+}
+
+// Returns a function that checks if a word contains the given word inside a longer word.
+func wordHidesWord(hidden string) func(word string) bool {
+	return func(word string) bool {
+		return len(word) > len(hidden) && strings.Contains(word, hidden)
+	}
+}
+
+// Returns a function that checks if a word without its first letter is a dictionary word.
+func wordWithoutFirstLetterIsWord(ctx *Context) func(word string) bool {
+	return func(word string) bool {
+		_, size := utf8.DecodeRuneInString(word)
+		return size < len(word) && ctx.IsWord(word[size:])
+	}
+}
+
+// Returns a function that checks if a word is an anagram of a different dictionary word.
+func wordIsAnagramOfWord(ctx *Context) func(word string) bool {
+	return func(word string) bool {
+		n := ctx.AnagramCount(word)
+		if ctx.IsWord(word) {
+			n--
+		}
+		return n > 0
+	}
+}
+
+// Returns a function that checks if a word's score is at least the given score.
+func wordScoreAtLeast(values LetterValues, score int) func(word string) bool {
+	return func(word string) bool {
+		return values.Score(word) >= score
+	}
+}
+
+// Returns a function that checks if a word's score is at most the given score.
+func wordScoreAtMost(values LetterValues, score int) func(word string) bool {
+	return func(word string) bool {
+		return values.Score(word) <= score
+	}
+}
+
+// Returns a function that checks if a word contains a letter worth the given value.
+func wordContainsLetterWorth(values LetterValues, value int) func(word string) bool {
+	return func(word string) bool {
+		for _, r := range word {
+			if values[r] == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Returns a function that checks if every letter of a word is worth the given value.
+func wordUsesOnlyLettersWorth(values LetterValues, value int) func(word string) bool {
+	return func(word string) bool {
+		for _, r := range word {
+			if values[r] != value {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Returns a function that checks if the letter at the given index of a word is one of the given letters.
+// Negative indexes count from the end of the word, so -1 is the last letter.
+func wordLetterAtIsOneOf(index int, letters string) func(word string) bool {
+	return func(word string) bool {
+		runes := []rune(word)
+		i := index
+		if i < 0 {
+			i += len(runes)
+		}
+		return i >= 0 && i < len(runes) && strings.ContainsRune(letters, runes[i])
+	}
+}
+
+// Returns a function that checks if a word matches a pattern letter by letter,
+// where "_" in the pattern matches any letter.
+func wordMatchesWildcard(pattern string) func(word string) bool {
+	patternLetters := []rune(pattern)
+	return func(word string) bool {
+		letters := []rune(word)
+		if len(letters) != len(patternLetters) {
+			return false
+		}
+		for i, p := range patternLetters {
+			if p != '_' && p != letters[i] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Converts a crossword-style pattern to an anchored regular expression.
+// In the pattern, "?" or "_" matches any letter, "*" matches any run of
+// letters, and "[aeiou]" matches any of the listed letters. Other characters
+// match themselves.
+func crosswordToRegexp(pattern string) (string, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c, size := utf8.DecodeRuneInString(pattern[i:])
+		switch c {
+		case '?', '_':
+			expr.WriteString(".")
+		case '*':
+			expr.WriteString(".*")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 2 {
+				return "", fmt.Errorf("unterminated or empty letter class at offset %d", i)
+			}
+			// Letters may be separated by commas, as in lists: [a, e].
+			class := strings.NewReplacer(",", "", " ", "").Replace(pattern[i+1 : i+end])
+			if class == "" || strings.ContainsAny(class, "[\\") {
+				return "", fmt.Errorf("invalid letter class %q", pattern[i+1:i+end])
+			}
+			expr.WriteString("[" + class + "]")
+			i += end
+		case ']':
+			return "", fmt.Errorf("unmatched ] at offset %d", i)
 		default:
-			panic("Cannot parse number: " + num)
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+			i += size - 1
+		}
+	}
+	expr.WriteString("$")
+
+	if _, err := regexp.Compile(expr.String()); err != nil {
+		return "", err
+	}
+	return expr.String(), nil
+}
+
+// Returns a function that checks if a word matches the given regular expression.
+func wordMatchesRegexp(re *regexp.Regexp) func(word string) bool {
+	return func(word string) bool {
+		return re.MatchString(word)
+	}
+}
+
+const (
+	vowels = "aeiou"
+	// Y is treated as a consonant.
+	consonants = "bcdfghjklmnpqrstvwxyz"
+)
+
+// Rows of a QWERTY keyboard.
+const (
+	keyboardTopRow    = "qwertyuiop"
+	keyboardMiddleRow = "asdfghjkl"
+	keyboardBottomRow = "zxcvbnm"
+)
+
+// Letters typed by each hand on a QWERTY keyboard.
+const (
+	leftHandLetters  = "qwertasdfgzxcvb"
+	rightHandLetters = "yuiophjklnm"
+)
+
+// Returns a function that checks if every letter of a word is one of the given letters.
+func wordUsesOnly(letters string) func(word string) bool {
+	return func(word string) bool {
+		for _, r := range word {
+			if !strings.ContainsRune(letters, r) {
+				return false
+			}
 		}
-	case predicate == "infinity":
-		return func(word string) bool {
-			return true
+		return true
+	}
+}
+
+// Returns the letters from first to last inclusive, e.g. "abcd" for 'a' and 'd'.
+func letterRange(first, last byte) string {
+	var b strings.Builder
+	for c := first; c <= last; c++ {
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// Returns a function that checks if a word starts with any of the given letters.
+func wordStartsWithOneOf(letters string) func(word string) bool {
+	return func(word string) bool {
+		first, _ := utf8.DecodeRuneInString(word)
+		return len(word) > 0 && strings.ContainsRune(letters, first)
+	}
+}
+
+// Returns a function that checks if a word ends with any of the given letters.
+func wordEndsWithOneOf(letters string) func(word string) bool {
+	return func(word string) bool {
+		last, _ := utf8.DecodeLastRuneInString(word)
+		return len(word) > 0 && strings.ContainsRune(letters, last)
+	}
+}
+
+// Returns a function that checks if a word has at least count letters from the given letters.
+func wordContainsAtLeast(letters string, count int) func(word string) bool {
+	return func(word string) bool {
+		n := 0
+		for _, r := range word {
+			if strings.ContainsRune(letters, r) {
+				n++
+			}
 		}
-	default:
-		panic("Cannot parse predicate: " + predicate)
+		return n >= count
+	}
+}
+
+// Parses a string like "Starts with mi" and returns a corresponding predicate.
+// Returns an *UnknownClueError, *MalformedNumberError, *UnknownNumberWordError,
+// *InvalidPositionError or *InvalidPatternError if the string cannot be parsed. Clues that consult the dictionary see an
+// empty one; use parseClue and Compile to evaluate them against a Context.
+func parsePredicate(predicate string) (func(word string) bool, error) {
+	n, err := parseClue(predicate)
+	if err != nil {
+		return nil, err
 	}
+	return n.Compile(emptyContext), nil
 }
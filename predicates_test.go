@@ -1,6 +1,9 @@
 package main
 
 import (
+	"errors"
+	"reflect"
+	"regexp"
 	"testing"
 )
 
@@ -728,6 +731,1258 @@ func TestWordContainsMoreThanOne(t *testing.T) {
 	}
 }
 
+func TestWordStartsWithOneOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		letters  string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "starts with a vowel",
+			letters:  vowels,
+			word:     "apple",
+			expected: true,
+		},
+		{
+			name:     "starts with a consonant",
+			letters:  vowels,
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "y is a consonant",
+			letters:  consonants,
+			word:     "yes",
+			expected: true,
+		},
+		{
+			name:     "empty word",
+			letters:  vowels,
+			word:     "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordStartsWithOneOf(tt.letters)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordStartsWithOneOf(%q)(%q) = %v, want %v", tt.letters, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordEndsWithOneOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		letters  string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "ends with a vowel",
+			letters:  vowels,
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "ends with a consonant",
+			letters:  vowels,
+			word:     "world",
+			expected: false,
+		},
+		{
+			name:     "y is a consonant",
+			letters:  consonants,
+			word:     "happy",
+			expected: true,
+		},
+		{
+			name:     "empty word",
+			letters:  consonants,
+			word:     "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordEndsWithOneOf(tt.letters)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordEndsWithOneOf(%q)(%q) = %v, want %v", tt.letters, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordContainsAtLeast(t *testing.T) {
+	tests := []struct {
+		name     string
+		letters  string
+		count    int
+		word     string
+		expected bool
+	}{
+		{
+			name:     "exactly enough vowels",
+			letters:  vowels,
+			count:    3,
+			word:     "banana",
+			expected: true,
+		},
+		{
+			name:     "too few vowels",
+			letters:  vowels,
+			count:    3,
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "repeated letters are counted",
+			letters:  consonants,
+			count:    3,
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "zero count matches empty word",
+			letters:  vowels,
+			count:    0,
+			word:     "",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordContainsAtLeast(tt.letters, tt.count)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordContainsAtLeast(%q, %d)(%q) = %v, want %v", tt.letters, tt.count, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordHasTripleLetter(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "triple letter",
+			word:     "brrr",
+			expected: true,
+		},
+		{
+			name:     "double letter only",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "two separate pairs",
+			word:     "aabaa",
+			expected: false,
+		},
+		{
+			name:     "short word",
+			word:     "aa",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordHasTripleLetter()
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordHasTripleLetter()(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordHasDoubleLetters(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		word     string
+		expected bool
+	}{
+		{
+			name:     "two different double letters",
+			count:    2,
+			word:     "balloon",
+			expected: true,
+		},
+		{
+			name:     "three different double letters",
+			count:    2,
+			word:     "bookkeeper",
+			expected: true,
+		},
+		{
+			name:     "same double letter twice",
+			count:    2,
+			word:     "aabaa",
+			expected: false,
+		},
+		{
+			name:     "one double letter",
+			count:    2,
+			word:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordHasDoubleLetters(tt.count)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordHasDoubleLetters(%d)(%q) = %v, want %v", tt.count, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordHasNoRepeatedLetters(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "isogram",
+			word:     "world",
+			expected: true,
+		},
+		{
+			name:     "adjacent repeat",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "distant repeat",
+			word:     "abca",
+			expected: false,
+		},
+		{
+			name:     "empty word",
+			word:     "",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordHasNoRepeatedLetters()
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordHasNoRepeatedLetters()(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordHasDistinctLetters(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    int
+		word     string
+		expected bool
+	}{
+		{
+			name:     "exact number of distinct letters",
+			count:    4,
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "fewer distinct letters",
+			count:    5,
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "more distinct letters",
+			count:    4,
+			word:     "world",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordHasDistinctLetters(tt.count)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordHasDistinctLetters(%d)(%q) = %v, want %v", tt.count, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordContainsExactly(t *testing.T) {
+	tests := []struct {
+		name     string
+		letter   string
+		count    int
+		word     string
+		expected bool
+	}{
+		{
+			name:     "exact count",
+			letter:   "l",
+			count:    2,
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "too many",
+			letter:   "l",
+			count:    1,
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "zero occurrences",
+			letter:   "z",
+			count:    0,
+			word:     "hello",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordContainsExactly(tt.letter, tt.count)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordContainsExactly(%q, %d)(%q) = %v, want %v", tt.letter, tt.count, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordIsPalindrome(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "odd length palindrome",
+			word:     "level",
+			expected: true,
+		},
+		{
+			name:     "even length palindrome",
+			word:     "abba",
+			expected: true,
+		},
+		{
+			name:     "not a palindrome",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "single letter",
+			word:     "a",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordIsPalindrome()
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordIsPalindrome()(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordStartsAndEndsWithSameLetter(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "same letter",
+			word:     "area",
+			expected: true,
+		},
+		{
+			name:     "different letters",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "single letter",
+			word:     "a",
+			expected: true,
+		},
+		{
+			name:     "empty word",
+			word:     "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordStartsAndEndsWithSameLetter()
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordStartsAndEndsWithSameLetter()(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordReversedIsWord(t *testing.T) {
+	ctx := newContext([]string{"stressed", "desserts", "level", "hello"})
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "reversal is a word",
+			word:     "stressed",
+			expected: true,
+		},
+		{
+			name:     "reversal is not a word",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "palindromes do not count",
+			word:     "level",
+			expected: false,
+		},
+		{
+			name:     "word itself need not be in the dictionary",
+			word:     "olleh",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordReversedIsWord(ctx)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordReversedIsWord(ctx)(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordContainsWord(t *testing.T) {
+	ctx := newContext([]string{"cat", "cats", "scatter", "at", "dog"})
+	tests := []struct {
+		name      string
+		minLength int
+		word      string
+		expected  bool
+	}{
+		{
+			name:      "contains a word",
+			minLength: 3,
+			word:      "scatter",
+			expected:  true,
+		},
+		{
+			name:      "word itself does not count",
+			minLength: 3,
+			word:      "cat",
+			expected:  false,
+		},
+		{
+			name:      "contained word too short",
+			minLength: 3,
+			word:      "hat",
+			expected:  false,
+		},
+		{
+			name:      "shorter minimum",
+			minLength: 2,
+			word:      "hat",
+			expected:  true,
+		},
+		{
+			name:      "no word inside",
+			minLength: 3,
+			word:      "hello",
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordContainsWord(ctx, tt.minLength)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordContainsWord(ctx, %d)(%q) = %v, want %v", tt.minLength, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordWithoutFirstLetterIsWord(t *testing.T) {
+	ctx := newContext([]string{"rice", "price", "a"})
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "remains a word",
+			word:     "price",
+			expected: true,
+		},
+		{
+			name:     "does not remain a word",
+			word:     "rice",
+			expected: false,
+		},
+		{
+			name:     "single letter",
+			word:     "a",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordWithoutFirstLetterIsWord(ctx)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordWithoutFirstLetterIsWord(ctx)(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordIsAnagramOfWord(t *testing.T) {
+	ctx := newContext([]string{"listen", "silent", "hello", "aa"})
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "anagram in dictionary",
+			word:     "listen",
+			expected: true,
+		},
+		{
+			name:     "no anagram in dictionary",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "word only anagram of itself",
+			word:     "aa",
+			expected: false,
+		},
+		{
+			name:     "word not in dictionary",
+			word:     "enlist",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordIsAnagramOfWord(ctx)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordIsAnagramOfWord(ctx)(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordStartsWithWord(t *testing.T) {
+	ctx := newContext([]string{"cat", "catalog", "he"})
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "starts with a word",
+			word:     "catalog",
+			expected: true,
+		},
+		{
+			name:     "word itself does not count",
+			word:     "cat",
+			expected: false,
+		},
+		{
+			name:     "prefix word too short",
+			word:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordStartsWithWord(ctx, 3)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordStartsWithWord(ctx, 3)(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordEndsWithWord(t *testing.T) {
+	ctx := newContext([]string{"log", "catalog", "lo"})
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "ends with a word",
+			word:     "catalog",
+			expected: true,
+		},
+		{
+			name:     "word itself does not count",
+			word:     "log",
+			expected: false,
+		},
+		{
+			name:     "suffix word too short",
+			word:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordEndsWithWord(ctx, 3)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordEndsWithWord(ctx, 3)(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordHidesWord(t *testing.T) {
+	tests := []struct {
+		name     string
+		hidden   string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "hidden inside",
+			hidden:   "cat",
+			word:     "scatter",
+			expected: true,
+		},
+		{
+			name:     "word itself",
+			hidden:   "cat",
+			word:     "cat",
+			expected: false,
+		},
+		{
+			name:     "not hidden",
+			hidden:   "cat",
+			word:     "chart",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordHidesWord(tt.hidden)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordHidesWord(%q)(%q) = %v, want %v", tt.hidden, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordScoreAtLeast(t *testing.T) {
+	tests := []struct {
+		name     string
+		score    int
+		word     string
+		expected bool
+	}{
+		{
+			name:     "score above",
+			score:    15,
+			word:     "quiz",
+			expected: true,
+		},
+		{
+			name:     "score equal",
+			score:    8,
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "score below",
+			score:    9,
+			word:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordScoreAtLeast(scrabbleValues, tt.score)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordScoreAtLeast(scrabbleValues, %d)(%q) = %v, want %v", tt.score, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordScoreAtMost(t *testing.T) {
+	tests := []struct {
+		name     string
+		score    int
+		word     string
+		expected bool
+	}{
+		{
+			name:     "score equal",
+			score:    8,
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "score above",
+			score:    7,
+			word:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordScoreAtMost(scrabbleValues, tt.score)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordScoreAtMost(scrabbleValues, %d)(%q) = %v, want %v", tt.score, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordContainsLetterWorth(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    int
+		word     string
+		expected bool
+	}{
+		{
+			name:     "contains a 10-point letter",
+			value:    10,
+			word:     "quiz",
+			expected: true,
+		},
+		{
+			name:     "no 10-point letter",
+			value:    10,
+			word:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordContainsLetterWorth(scrabbleValues, tt.value)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordContainsLetterWorth(scrabbleValues, %d)(%q) = %v, want %v", tt.value, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordUsesOnlyLettersWorth(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    int
+		word     string
+		expected bool
+	}{
+		{
+			name:     "only 1-point letters",
+			value:    1,
+			word:     "tulle",
+			expected: true,
+		},
+		{
+			name:     "has a 4-point letter",
+			value:    1,
+			word:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordUsesOnlyLettersWorth(scrabbleValues, tt.value)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordUsesOnlyLettersWorth(scrabbleValues, %d)(%q) = %v, want %v", tt.value, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordLetterAtIsOneOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		index    int
+		letters  string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "second letter matches",
+			index:    1,
+			letters:  "e",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "second letter does not match",
+			index:    1,
+			letters:  "o",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "last letter",
+			index:    -1,
+			letters:  "o",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "third-to-last letter",
+			index:    -3,
+			letters:  "l",
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "letter is a vowel",
+			index:    4,
+			letters:  vowels,
+			word:     "hello",
+			expected: true,
+		},
+		{
+			name:     "index past end",
+			index:    5,
+			letters:  "o",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "negative index past start",
+			index:    -6,
+			letters:  "h",
+			word:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordLetterAtIsOneOf(tt.index, tt.letters)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordLetterAtIsOneOf(%d, %q)(%q) = %v, want %v", tt.index, tt.letters, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordMatchesWildcard(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "wildcard matches",
+			pattern:  "c_t",
+			word:     "cat",
+			expected: true,
+		},
+		{
+			name:     "literal mismatch",
+			pattern:  "c_t",
+			word:     "cab",
+			expected: false,
+		},
+		{
+			name:     "length mismatch",
+			pattern:  "c_t",
+			word:     "cart",
+			expected: false,
+		},
+		{
+			name:     "all wildcards",
+			pattern:  "___",
+			word:     "dog",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordMatchesWildcard(tt.pattern)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordMatchesWildcard(%q)(%q) = %v, want %v", tt.pattern, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCrosswordToRegexp(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "question mark matches one letter",
+			pattern:  "c?t",
+			word:     "cat",
+			expected: true,
+		},
+		{
+			name:     "underscore matches one letter",
+			pattern:  "c_t",
+			word:     "cut",
+			expected: true,
+		},
+		{
+			name:     "question mark does not match two letters",
+			pattern:  "c?t",
+			word:     "cart",
+			expected: false,
+		},
+		{
+			name:     "star matches a run",
+			pattern:  "*ing",
+			word:     "string",
+			expected: true,
+		},
+		{
+			name:     "star matches nothing",
+			pattern:  "ing*",
+			word:     "ing",
+			expected: true,
+		},
+		{
+			name:     "letter class",
+			pattern:  "[aeiou]*[aeiou]",
+			word:     "area",
+			expected: true,
+		},
+		{
+			name:     "letter class mismatch",
+			pattern:  "[aeiou]*[aeiou]",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "regexp characters are literal",
+			pattern:  "a.c",
+			word:     "abc",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := crosswordToRegexp(tt.pattern)
+			if err != nil {
+				t.Fatalf("crosswordToRegexp(%q) returned error: %v", tt.pattern, err)
+			}
+			predicate := wordMatchesRegexp(regexp.MustCompile(expr))
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("crosswordToRegexp(%q) = %q, matches %q = %v, want %v", tt.pattern, expr, tt.word, result, tt.expected)
+			}
+		})
+	}
+
+	for _, pattern := range []string{"[abc", "[]", "ab]", "[a[b]"} {
+		if _, err := crosswordToRegexp(pattern); err == nil {
+			t.Errorf("crosswordToRegexp(%q) returned no error", pattern)
+		}
+	}
+}
+
+func TestWordLettersInAlphabeticalOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "strictly increasing",
+			word:     "abc",
+			expected: true,
+		},
+		{
+			name:     "repeated letters allowed",
+			word:     "billowy",
+			expected: true,
+		},
+		{
+			name:     "out of order",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "empty word",
+			word:     "",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordLettersInAlphabeticalOrder()
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordLettersInAlphabeticalOrder()(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordLettersInReverseAlphabeticalOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "strictly decreasing",
+			word:     "zyx",
+			expected: true,
+		},
+		{
+			name:     "repeated letters allowed",
+			word:     "spoonfed",
+			expected: true,
+		},
+		{
+			name:     "out of order",
+			word:     "hello",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordLettersInReverseAlphabeticalOrder()
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordLettersInReverseAlphabeticalOrder()(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordFirstLetterAfterLastLetter(t *testing.T) {
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "first after last",
+			word:     "world",
+			expected: true,
+		},
+		{
+			name:     "first before last",
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "same letter",
+			word:     "area",
+			expected: false,
+		},
+		{
+			name:     "empty word",
+			word:     "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordFirstLetterAfterLastLetter()
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordFirstLetterAfterLastLetter()(%q) = %v, want %v", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordHasAlphabeticalRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		length   int
+		word     string
+		expected bool
+	}{
+		{
+			name:     "run at start",
+			length:   3,
+			word:     "abcess",
+			expected: true,
+		},
+		{
+			name:     "run in middle",
+			length:   3,
+			word:     "first",
+			expected: true,
+		},
+		{
+			name:     "run of exactly three",
+			length:   3,
+			word:     "hijack",
+			expected: true,
+		},
+		{
+			name:     "broken run",
+			length:   3,
+			word:     "abdc",
+			expected: false,
+		},
+		{
+			name:     "descending run does not count",
+			length:   3,
+			word:     "cba",
+			expected: false,
+		},
+		{
+			name:     "single letter run",
+			length:   1,
+			word:     "a",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordHasAlphabeticalRun(tt.length)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordHasAlphabeticalRun(%d)(%q) = %v, want %v", tt.length, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWordUsesOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		letters  string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "only allowed letters",
+			letters:  letterRange('a', 'm'),
+			word:     "deal",
+			expected: true,
+		},
+		{
+			name:     "has a forbidden letter",
+			letters:  letterRange('a', 'm'),
+			word:     "hello",
+			expected: false,
+		},
+		{
+			name:     "top row of a keyboard",
+			letters:  keyboardTopRow,
+			word:     "typewriter",
+			expected: true,
+		},
+		{
+			name:     "left hand",
+			letters:  leftHandLetters,
+			word:     "stewardess",
+			expected: true,
+		},
+		{
+			name:     "empty word",
+			letters:  vowels,
+			word:     "",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate := wordUsesOnly(tt.letters)
+			result := predicate(tt.word)
+			if result != tt.expected {
+				t.Errorf("wordUsesOnly(%q)(%q) = %v, want %v", tt.letters, tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLetterRange(t *testing.T) {
+	tests := []struct {
+		first, last byte
+		expected    string
+	}{
+		{'a', 'e', "abcde"},
+		{'x', 'x', "x"},
+		{'m', 'a', ""},
+	}
+
+	for _, tt := range tests {
+		if got := letterRange(tt.first, tt.last); got != tt.expected {
+			t.Errorf("letterRange(%q, %q) = %q, want %q", tt.first, tt.last, got, tt.expected)
+		}
+	}
+}
+
 func TestParsePredicate(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -737,197 +1992,677 @@ func TestParsePredicate(t *testing.T) {
 	}{
 		// Starts with
 		{
-			name:      "starts with prefix",
-			predicate: "Starts with he",
+			name:      "starts with prefix",
+			predicate: "Starts with he",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "starts with prefix - mismatch",
+			predicate: "Starts with xy",
+			word:      "hello",
+			expected:  false,
+		},
+		// Ends with
+		{
+			name:      "ends with suffix",
+			predicate: "Ends with lo",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "ends with suffix - mismatch",
+			predicate: "Ends with xy",
+			word:      "hello",
+			expected:  false,
+		},
+		// Contains the letter
+		{
+			name:      "contains the letter",
+			predicate: "Contains the letter e",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "contains the letter - mismatch",
+			predicate: "Contains the letter z",
+			word:      "hello",
+			expected:  false,
+		},
+		// Contains multiple
+		{
+			name:      "contains multiple letters",
+			predicate: "Contains e, l",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "contains multiple letters - one missing",
+			predicate: "Contains e, z",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "contains sequence",
+			predicate: "Contains el",
+			word:      "hello",
+			expected:  true,
+		},
+		// Does not contain
+		{
+			name:      "does not contain letters",
+			predicate: "Does not contain z, y",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "does not contain letters - one present",
+			predicate: "Does not contain z, e",
+			word:      "hello",
+			expected:  false,
+		},
+		// Between X and Y letters
+		{
+			name:      "between length range - within range",
+			predicate: "Between 4 and 6 letters",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "between length range - below range",
+			predicate: "Between 6 and 10 letters",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "between length range - above range",
+			predicate: "Between 2 and 4 letters",
+			word:      "hello",
+			expected:  false,
+		},
+		// Multiple letter X's
+		{
+			name:      "multiple letter",
+			predicate: "Multiple letter l's",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "multiple letter - only one occurrence",
+			predicate: "Multiple letter h's",
+			word:      "hello",
+			expected:  false,
+		},
+		// Multiple X's
+		{
+			name:      "multiple shorthand",
+			predicate: "Multiple l's",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "multiple shorthand - only one occurrence",
+			predicate: "Multiple h's",
+			word:      "hello",
+			expected:  false,
+		},
+		// Double letter
+		{
+			name:      "double letter - present",
+			predicate: "Double letter",
 			word:      "hello",
 			expected:  true,
 		},
 		{
-			name:      "starts with prefix - mismatch",
-			predicate: "Starts with xy",
+			name:      "double letter - absent",
+			predicate: "Double letter",
+			word:      "world",
+			expected:  false,
+		},
+		// Starts & ends with
+		{
+			name:      "starts and ends with same letter",
+			predicate: "Starts & ends with a",
+			word:      "area",
+			expected:  true,
+		},
+		{
+			name:      "starts and ends with same letter - mismatch",
+			predicate: "Starts & ends with h",
+			word:      "hello",
+			expected:  false,
+		},
+		// X letters or fewer
+		{
+			name:      "letters or fewer - within limit",
+			predicate: "5 letters or fewer",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "letters or fewer - exceeds limit",
+			predicate: "4 letters or fewer",
+			word:      "hello",
+			expected:  false,
+		},
+		// X letters or more
+		{
+			name:      "letters or more - meets minimum",
+			predicate: "5 letters or more",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "letters or more - below minimum",
+			predicate: "6 letters or more",
+			word:      "hello",
+			expected:  false,
+		},
+		// X letter word
+		{
+			name:      "letter word - five",
+			predicate: "five letter word",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "letter word - four",
+			predicate: "four letter word",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "letter word - twelve",
+			predicate: "Twelve letter word",
+			word:      "considerable",
+			expected:  true,
+		},
+		{
+			name:      "letter word - digits",
+			predicate: "5 letter word",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "letters or fewer - spelled out",
+			predicate: "Five letters or fewer",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "letters or more - spelled out",
+			predicate: "Twenty-one letters or more",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "between - spelled out",
+			predicate: "Between eight and twelve letters",
+			word:      "considerable",
+			expected:  true,
+		},
+		{
+			name:      "between - mixed",
+			predicate: "Between four and 6 letters",
+			word:      "hello",
+			expected:  true,
+		},
+		// Infinity
+		{
+			name:      "infinity predicate",
+			predicate: "infinity",
+			word:      "anyword",
+			expected:  true,
+		},
+		// Letter uniqueness and repetition
+		{
+			name:      "no repeated letters",
+			predicate: "No repeated letters",
+			word:      "world",
+			expected:  true,
+		},
+		{
+			name:      "no repeated letters - repeat",
+			predicate: "No repeated letters",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "exactly distinct letters",
+			predicate: "Exactly 4 distinct letters",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "triple letter",
+			predicate: "Triple letter",
+			word:      "brrr",
+			expected:  true,
+		},
+		{
+			name:      "two different double letters",
+			predicate: "Two different double letters",
+			word:      "balloon",
+			expected:  true,
+		},
+		{
+			name:      "two different double letters - one pair",
+			predicate: "Two different double letters",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "letter appears exactly",
+			predicate: "Letter l appears exactly 2 times",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "letter appears exactly - mismatch",
+			predicate: "Letter l appears exactly 1 times",
+			word:      "hello",
+			expected:  false,
+		},
+		// Palindromes and symmetry
+		{
+			name:      "is a palindrome",
+			predicate: "Is a palindrome",
+			word:      "level",
+			expected:  true,
+		},
+		{
+			name:      "is a palindrome - mismatch",
+			predicate: "Is a palindrome",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "starts and ends with the same letter",
+			predicate: "Starts and ends with the same letter",
+			word:      "area",
+			expected:  true,
+		},
+		{
+			name:      "starts and ends with the same letter - mismatch",
+			predicate: "Starts and ends with the same letter",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "reversed is also a word - no dictionary",
+			predicate: "Reversed is also a word",
+			word:      "stressed",
+			expected:  false,
+		},
+		// Letter values
+		{
+			name:      "scrabble score or more",
+			predicate: "Scrabble score of 15 or more",
+			word:      "quiz",
+			expected:  true,
+		},
+		{
+			name:      "scrabble score or more - too low",
+			predicate: "Scrabble score of 15 or more",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "scrabble score or less",
+			predicate: "Scrabble score of 8 or less",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "contains a 10-point letter",
+			predicate: "Contains a 10-point letter",
+			word:      "quiz",
+			expected:  true,
+		},
+		{
+			name:      "uses only 1-point letters",
+			predicate: "Uses only 1-point letters",
+			word:      "tulle",
+			expected:  true,
+		},
+		// Positional letters
+		{
+			name:      "second letter is",
+			predicate: "Second letter is e",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "second letter is - mismatch",
+			predicate: "Second letter is o",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "numeric ordinal",
+			predicate: "2nd letter is e",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "third-to-last letter is",
+			predicate: "Third-to-last letter is l",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "last letter is",
+			predicate: "Last letter is o",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "letter at position is a vowel",
+			predicate: "Letter at position 2 is a vowel",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "letter at position is a vowel - consonant",
+			predicate: "Letter at position 3 is a vowel",
 			word:      "hello",
 			expected:  false,
 		},
-		// Ends with
 		{
-			name:      "ends with suffix",
-			predicate: "Ends with lo",
-			word:      "hello",
+			name:      "letter at position is",
+			predicate: "Letter at position 3 is l",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "ordinal letter is a consonant",
+			predicate: "First letter is a consonant",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "matches wildcard",
+			predicate: "Matches h_ll_",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "matches wildcard - mismatch",
+			predicate: "Matches h_ll",
+			word:      "hello",
+			expected:  false,
+		},
+		// Patterns
+		{
+			name:      "matches pattern",
+			predicate: "Matches pattern h?l*",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "matches pattern - mismatch",
+			predicate: "Matches pattern h?l",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "matches pattern - comma-separated class",
+			predicate: "Matches pattern [a,e]pple",
+			word:      "apple",
+			expected:  true,
+		},
+		{
+			name:      "matches pattern - spaced class",
+			predicate: "Matches pattern [a, e]pple",
+			word:      "ipple",
+			expected:  false,
+		},
+		{
+			name:      "matches regex",
+			predicate: "Matches regex /^h(e|a)l+o$/",
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "matches regex keeps case of escapes",
+			predicate: `Matches regex /^\D+$/`,
+			word:      "hello",
+			expected:  true,
+		},
+		{
+			name:      "matches regex with escaped slash",
+			predicate: `Matches regex /^a\/b$/`,
+			word:      "a/b",
+			expected:  true,
+		},
+		// Alphabetical order
+		{
+			name:      "letters in alphabetical order",
+			predicate: "Letters in alphabetical order",
+			word:      "almost",
+			expected:  true,
+		},
+		{
+			name:      "letters in alphabetical order - mismatch",
+			predicate: "Letters in alphabetical order",
+			word:      "hello",
+			expected:  false,
+		},
+		{
+			name:      "letters in reverse alphabetical order",
+			predicate: "Letters in reverse alphabetical order",
+			word:      "wronged",
+			expected:  true,
+		},
+		{
+			name:      "first letter comes after last letter",
+			predicate: "First letter comes after last letter",
+			word:      "world",
+			expected:  true,
+		},
+		{
+			name:      "contains three consecutive alphabet letters",
+			predicate: "Contains three consecutive alphabet letters",
+			word:      "first",
+			expected:  true,
+		},
+		{
+			name:      "contains three consecutive alphabet letters - mismatch",
+			predicate: "Contains three consecutive alphabet letters",
+			word:      "hello",
+			expected:  false,
+		},
+		// Non-ASCII words
+		{
+			name:      "letter word - counts letters not bytes",
+			predicate: "Four letter word",
+			word:      "café",
+			expected:  true,
+		},
+		{
+			name:      "starts with - clue is normalized",
+			predicate: "Starts with E\u0301",
+			word:      "école",
 			expected:  true,
 		},
 		{
-			name:      "ends with suffix - mismatch",
-			predicate: "Ends with xy",
-			word:      "hello",
+			name:      "ends with a vowel - accented letter",
+			predicate: "Ends with a vowel",
+			word:      "café",
 			expected:  false,
 		},
-		// Contains the letter
 		{
-			name:      "contains the letter",
-			predicate: "Contains the letter e",
-			word:      "hello",
+			name:      "double letter - non-ascii",
+			predicate: "Double letter",
+			word:      "straße",
+			expected:  false,
+		},
+		{
+			name:      "palindrome - non-ascii",
+			predicate: "Is a palindrome",
+			word:      "ésé",
 			expected:  true,
 		},
 		{
-			name:      "contains the letter - mismatch",
-			predicate: "Contains the letter z",
-			word:      "hello",
+			name:      "letter at position - non-ascii",
+			predicate: "Second letter is ç",
+			word:      "façade",
 			expected:  false,
 		},
-		// Contains multiple
 		{
-			name:      "contains multiple letters",
-			predicate: "Contains e, l",
-			word:      "hello",
+			name:      "third letter - non-ascii",
+			predicate: "Third letter is ç",
+			word:      "façade",
 			expected:  true,
 		},
 		{
-			name:      "contains multiple letters - one missing",
-			predicate: "Contains e, z",
-			word:      "hello",
+			name:      "matches pattern - non-ascii",
+			predicate: "Matches pattern ca?é",
+			word:      "cafés",
 			expected:  false,
 		},
 		{
-			name:      "contains sequence",
-			predicate: "Contains el",
-			word:      "hello",
+			name:      "matches pattern - non-ascii letter",
+			predicate: "Matches pattern ca?é",
+			word:      "café",
 			expected:  true,
 		},
-		// Does not contain
+		// Letter sets
 		{
-			name:      "does not contain letters",
-			predicate: "Does not contain z, y",
-			word:      "hello",
+			name:      "only uses letters from range",
+			predicate: "Only uses letters from A-M",
+			word:      "deal",
 			expected:  true,
 		},
 		{
-			name:      "does not contain letters - one present",
-			predicate: "Does not contain z, e",
+			name:      "only uses letters from range - outside",
+			predicate: "Only uses letters from a-m",
 			word:      "hello",
 			expected:  false,
 		},
-		// Between X and Y letters
 		{
-			name:      "between length range - within range",
-			predicate: "Between 4 and 6 letters",
-			word:      "hello",
+			name:      "uses no letters from range",
+			predicate: "Uses no letters from n-z",
+			word:      "deal",
 			expected:  true,
 		},
 		{
-			name:      "between length range - below range",
-			predicate: "Between 6 and 10 letters",
+			name:      "only uses the letters",
+			predicate: "Only uses the letters h, e, l, o",
 			word:      "hello",
-			expected:  false,
+			expected:  true,
 		},
 		{
-			name:      "between length range - above range",
-			predicate: "Between 2 and 4 letters",
+			name:      "uses none of the letters",
+			predicate: "Uses none of the letters a, i",
 			word:      "hello",
-			expected:  false,
+			expected:  true,
 		},
-		// Multiple letter X's
 		{
-			name:      "multiple letter",
-			predicate: "Multiple letter l's",
-			word:      "hello",
+			name:      "uses only the top row of a keyboard",
+			predicate: "Uses only the top row of a keyboard",
+			word:      "typewriter",
 			expected:  true,
 		},
 		{
-			name:      "multiple letter - only one occurrence",
-			predicate: "Multiple letter h's",
-			word:      "hello",
+			name:      "uses only the middle row of a keyboard - mismatch",
+			predicate: "Uses only the middle row of a keyboard",
+			word:      "typewriter",
 			expected:  false,
 		},
-		// Multiple X's
 		{
-			name:      "multiple shorthand",
-			predicate: "Multiple l's",
-			word:      "hello",
+			name:      "typed with the right hand",
+			predicate: "Typed with the right hand",
+			word:      "lollipop",
 			expected:  true,
 		},
 		{
-			name:      "multiple shorthand - only one occurrence",
-			predicate: "Multiple h's",
+			name:      "typed with one hand",
+			predicate: "Typed with one hand",
+			word:      "stewardess",
+			expected:  true,
+		},
+		{
+			name:      "typed with one hand - both hands",
+			predicate: "Typed with one hand",
 			word:      "hello",
 			expected:  false,
 		},
-		// Double letter
+		// Vowels and consonants
 		{
-			name:      "double letter - present",
-			predicate: "Double letter",
-			word:      "hello",
+			name:      "starts with a vowel",
+			predicate: "Starts with a vowel",
+			word:      "apple",
 			expected:  true,
 		},
 		{
-			name:      "double letter - absent",
-			predicate: "Double letter",
-			word:      "world",
+			name:      "starts with a vowel - mismatch",
+			predicate: "Starts with a vowel",
+			word:      "hello",
 			expected:  false,
 		},
-		// Starts & ends with
 		{
-			name:      "starts and ends with same letter",
-			predicate: "Starts & ends with a",
-			word:      "area",
+			name:      "ends with a consonant",
+			predicate: "Ends with a consonant",
+			word:      "world",
 			expected:  true,
 		},
 		{
-			name:      "starts and ends with same letter - mismatch",
-			predicate: "Starts & ends with h",
+			name:      "ends with a consonant - mismatch",
+			predicate: "Ends with a consonant",
 			word:      "hello",
 			expected:  false,
 		},
-		// X letters or fewer
 		{
-			name:      "letters or fewer - within limit",
-			predicate: "5 letters or fewer",
-			word:      "hello",
+			name:      "at least vowels",
+			predicate: "At least 3 vowels",
+			word:      "banana",
 			expected:  true,
 		},
 		{
-			name:      "letters or fewer - exceeds limit",
-			predicate: "4 letters or fewer",
+			name:      "at least vowels - too few",
+			predicate: "At least 3 vowels",
 			word:      "hello",
 			expected:  false,
 		},
-		// X letters or more
 		{
-			name:      "letters or more - meets minimum",
-			predicate: "5 letters or more",
-			word:      "hello",
+			name:      "no vowels except y",
+			predicate: "No vowels except y",
+			word:      "rhythm",
 			expected:  true,
 		},
 		{
-			name:      "letters or more - below minimum",
-			predicate: "6 letters or more",
+			name:      "no vowels except y - has vowel",
+			predicate: "No vowels except y",
 			word:      "hello",
 			expected:  false,
 		},
-		// X letter word
 		{
-			name:      "letter word - five",
-			predicate: "five letter word",
-			word:      "hello",
+			name:      "contains all five vowels",
+			predicate: "Contains all five vowels",
+			word:      "sequoia",
 			expected:  true,
 		},
 		{
-			name:      "letter word - four",
-			predicate: "four letter word",
-			word:      "hello",
-			expected:  false,
+			name:      "contains all five vowels - different order",
+			predicate: "Contains all five vowels",
+			word:      "education",
+			expected:  true,
 		},
-		// Infinity
 		{
-			name:      "infinity predicate",
-			predicate: "infinity",
-			word:      "anyword",
-			expected:  true,
+			name:      "contains all five vowels - missing one",
+			predicate: "Contains all five vowels",
+			word:      "abstemio",
+			expected:  false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			predicate := parsePredicate(tt.predicate)
+			predicate, err := parsePredicate(tt.predicate)
+			if err != nil {
+				t.Fatalf("parsePredicate(%q) returned error: %v", tt.predicate, err)
+			}
 			result := predicate(tt.word)
 			if result != tt.expected {
 				t.Errorf("parsePredicate(%q)(%q) = %v, want %v", tt.predicate, tt.word, result, tt.expected)
@@ -935,3 +2670,496 @@ func TestParsePredicate(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePredicateErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		predicate string
+		check     func(err error) bool
+	}{
+		{
+			name:      "unknown clue",
+			predicate: "Rhymes with orange",
+			check: func(err error) bool {
+				var target *UnknownClueError
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:      "malformed number - letters or fewer",
+			predicate: "x letters or fewer",
+			check: func(err error) bool {
+				var target *MalformedNumberError
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:      "malformed number - letters or more",
+			predicate: "many letters or more",
+			check: func(err error) bool {
+				var target *MalformedNumberError
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:      "malformed number - between",
+			predicate: "Between four and many letters",
+			check: func(err error) bool {
+				var target *MalformedNumberError
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:      "unknown number word",
+			predicate: "eleventy letter word",
+			check: func(err error) bool {
+				var target *UnknownNumberWordError
+				return errors.As(err, &target)
+			},
+		},
+		{
+			name:      "invalid position",
+			predicate: "Letter at position 0 is e",
+			check: func(err error) bool {
+				var target *InvalidPositionError
+				return errors.As(err, &target) && target.Position == 0
+			},
+		},
+		{
+			name:      "invalid position - vowel",
+			predicate: "Letter at position zero is a vowel",
+			check: func(err error) bool {
+				var target *InvalidPositionError
+				return errors.As(err, &target)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicate, err := parsePredicate(tt.predicate)
+			if predicate != nil {
+				t.Errorf("parsePredicate(%q) returned a predicate, want nil", tt.predicate)
+			}
+			if !tt.check(err) {
+				t.Errorf("parsePredicate(%q) error = %v, wrong type", tt.predicate, err)
+			}
+		})
+	}
+}
+
+func TestParseClue(t *testing.T) {
+	tests := []struct {
+		clue     string
+		expected Node
+	}{
+		{"Starts with mi", StartsWith{Prefix: "mi"}},
+		{"Ends with m", EndsWith{Suffix: "m"}},
+		{"Starts & ends with a", StartsAndEndsWith{Affix: "a"}},
+		{"Contains the letter e", Contains{Substring: "e"}},
+		{"Contains el", Contains{Substring: "el"}},
+		{"Contains e, l", And{Nodes: []Node{Contains{Substring: "e"}, Contains{Substring: "l"}}}},
+		{"Does not contain z", Not{Node: Contains{Substring: "z"}}},
+		{"Does not contain z, y", Not{Node: Or{Nodes: []Node{Contains{Substring: "z"}, Contains{Substring: "y"}}}}},
+		{"Between 3 and 6 letters", LengthRange{Min: 3, Max: 6}},
+		{"Multiple letter l’s", CountAtLeast{Substring: "l", N: 2}},
+		{"Multiple l's", CountAtLeast{Substring: "l", N: 2}},
+		{"Double letter", DoubleLetter{}},
+		{"5 letters or fewer", LengthRange{Min: 0, Max: 5}},
+		{"5 letters or more", LengthRange{Min: 5, Max: noMaxLength}},
+		{"five letter word", LengthRange{Min: 5, Max: 5}},
+		{"infinity", Always{}},
+		// Combinators
+		{"Starts with a or ends with e", Or{Nodes: []Node{StartsWith{Prefix: "a"}, EndsWith{Suffix: "e"}}}},
+		{"Not 5 letters or fewer", Not{Node: LengthRange{Min: 0, Max: 5}}},
+		{"Not not double letter", Not{Node: Not{Node: DoubleLetter{}}}},
+		{
+			"Starts with a and ends with e or double letter",
+			Or{Nodes: []Node{And{Nodes: []Node{StartsWith{Prefix: "a"}, EndsWith{Suffix: "e"}}}, DoubleLetter{}}},
+		},
+		{
+			"Starts with a and (ends with e or double letter)",
+			And{Nodes: []Node{StartsWith{Prefix: "a"}, Or{Nodes: []Node{EndsWith{Suffix: "e"}, DoubleLetter{}}}}},
+		},
+		{
+			"Between 3 and 6 letters and contains e, l",
+			And{Nodes: []Node{LengthRange{Min: 3, Max: 6}, And{Nodes: []Node{Contains{Substring: "e"}, Contains{Substring: "l"}}}}},
+		},
+		{
+			"Not (starts with a or starts with b) and starts & ends with c",
+			And{Nodes: []Node{
+				Not{Node: Or{Nodes: []Node{StartsWith{Prefix: "a"}, StartsWith{Prefix: "b"}}}},
+				StartsAndEndsWith{Affix: "c"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.clue, func(t *testing.T) {
+			n, err := parseClue(tt.clue)
+			if err != nil {
+				t.Fatalf("parseClue(%q) returned error: %v", tt.clue, err)
+			}
+			if !reflect.DeepEqual(n, tt.expected) {
+				t.Errorf("parseClue(%q) = %#v, want %#v", tt.clue, n, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNodeRoundTrip(t *testing.T) {
+	tests := []struct {
+		clue      string
+		canonical string
+	}{
+		{"Starts with mi", "Starts with mi"},
+		{"ENDS WITH M", "Ends with m"},
+		{"Starts & ends with a", "Starts & ends with a"},
+		{"Contains the letter e", "Contains e"},
+		{"Contains e,l", "Contains e, l"},
+		{"Does not contain z, y", "Does not contain z, y"},
+		{"Between 3 and 6 letters", "Between 3 and 6 letters"},
+		{"Between 0 and 6 letters", "6 letters or fewer"},
+		{"Between 4 and 4 letters", "Four letter word"},
+		{"Multiple letter l's", "Multiple l's"},
+		{"Double letter", "Double letter"},
+		{"5 letters or fewer", "5 letters or fewer"},
+		{"5 letters or more", "5 letters or more"},
+		{"five letter word", "Five letter word"},
+		{"Twenty-one letter word", "Twenty-one letter word"},
+		{"Between eight and twelve letters", "Between 8 and 12 letters"},
+		{"Fifteen letters or more", "15 letters or more"},
+		{"infinity", "Infinity"},
+		{"Starts with a OR ends with e", "Starts with a or ends with e"},
+		{"not 5 letters or fewer", "Not 5 letters or fewer"},
+		{"Starts with a and (ends with e or double letter)", "Starts with a and (ends with e or double letter)"},
+		{"(Starts with a and ends with e) or double letter", "Starts with a and ends with e or double letter"},
+		{"Not (contains e, l)", "Not contains e, l"},
+		{"Contains e and contains l", "Contains e, l"},
+		{"Does not contain z, y or double letter", "Does not contain z, y or double letter"},
+		{"Not (not five letter word or infinity)", "Not (not five letter word or infinity)"},
+		{"Matches pattern C?T", "Matches pattern c?t"},
+		{"Matches regex /A\\/b/ or double letter", "Matches regex /A\\/b/ or double letter"},
+		{"Matches regex /or/", "Matches regex /or/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.clue, func(t *testing.T) {
+			n, err := parseClue(tt.clue)
+			if err != nil {
+				t.Fatalf("parseClue(%q) returned error: %v", tt.clue, err)
+			}
+			if n.String() != tt.canonical {
+				t.Errorf("parseClue(%q).String() = %q, want %q", tt.clue, n.String(), tt.canonical)
+			}
+			reparsed, err := parseClue(n.String())
+			if err != nil {
+				t.Fatalf("parseClue(%q) returned error: %v", n.String(), err)
+			}
+			if !reflect.DeepEqual(reparsed, n) {
+				t.Errorf("parseClue(%q) = %#v, want %#v", n.String(), reparsed, n)
+			}
+		})
+	}
+}
+
+func TestParseClueCombinatorErrors(t *testing.T) {
+	for _, clue := range []string{
+		"Starts with a or",
+		"and ends with e",
+		"(Starts with a",
+		"Starts with a)",
+		"Not",
+		"()",
+	} {
+		var target *UnknownClueError
+		if _, err := parseClue(clue); !errors.As(err, &target) {
+			t.Errorf("parseClue(%q) error = %v, want *UnknownClueError", clue, err)
+		}
+	}
+}
+
+func TestParseClueInvalidRange(t *testing.T) {
+	for _, clue := range []string{"Only uses letters from m-a", "Only uses letters from a-", "Only uses letters from ab-c"} {
+		var target *UnknownClueError
+		if _, err := parseClue(clue); !errors.As(err, &target) {
+			t.Errorf("parseClue(%q) error = %v, want *UnknownClueError", clue, err)
+		}
+	}
+}
+
+func TestParseClueInvalidPattern(t *testing.T) {
+	for _, clue := range []string{"Matches pattern [abc", "Matches regex /a(b/"} {
+		var target *InvalidPatternError
+		if _, err := parseClue(clue); !errors.As(err, &target) {
+			t.Errorf("parseClue(%q) error = %v, want *InvalidPatternError", clue, err)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		clue     string
+		expected []token
+	}{
+		{
+			clue: "Contains E, l’s",
+			expected: []token{
+				{tokenWord, "contains"}, {tokenWord, "e"}, {tokenPunct, ","}, {tokenWord, "l's"},
+			},
+		},
+		{
+			clue: `Matches regex /^A(b|c) d\/$/ or (x)`,
+			expected: []token{
+				{tokenWord, "matches"}, {tokenWord, "regex"}, {tokenRegexp, `^A(b|c) d/$`},
+				{tokenWord, "or"}, {tokenPunct, "("}, {tokenWord, "x"}, {tokenPunct, ")"},
+			},
+		},
+		{
+			clue: "Matches pattern [A, e]pple or (b)",
+			expected: []token{
+				{tokenWord, "matches"}, {tokenWord, "pattern"}, {tokenWord, "[a, e]pple"},
+				{tokenWord, "or"}, {tokenPunct, "("}, {tokenWord, "b"}, {tokenPunct, ")"},
+			},
+		},
+		{
+			clue:     `/unterminated\d`,
+			expected: []token{{tokenRegexp, `unterminated\d`}},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tokenize(tt.clue); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("tokenize(%q) = %v, want %v", tt.clue, got, tt.expected)
+		}
+	}
+}
+
+func TestParsePredicateCombinators(t *testing.T) {
+	tests := []struct {
+		predicate string
+		word      string
+		expected  bool
+	}{
+		{"Starts with a or ends with e", "apple", true},
+		{"Starts with a or ends with e", "hope", true},
+		{"Starts with a or ends with e", "hello", false},
+		{"Not 5 letters or fewer", "hello", false},
+		{"Not 5 letters or fewer", "hellos", true},
+		{"Starts with h and (ends with e or double letter)", "hello", true},
+		{"Starts with h and (ends with e or double letter)", "helo", false},
+		{"Not (starts with h or starts with w)", "world", false},
+	}
+
+	for _, tt := range tests {
+		predicate, err := parsePredicate(tt.predicate)
+		if err != nil {
+			t.Fatalf("parsePredicate(%q) returned error: %v", tt.predicate, err)
+		}
+		if result := predicate(tt.word); result != tt.expected {
+			t.Errorf("parsePredicate(%q)(%q) = %v, want %v", tt.predicate, tt.word, result, tt.expected)
+		}
+	}
+}
+
+func TestCompileWithContext(t *testing.T) {
+	ctx := newContext([]string{"stressed", "desserts", "hello", "ello", "listen", "silent"})
+	tests := []struct {
+		clue     string
+		word     string
+		expected bool
+	}{
+		{"Reversed is also a word and not is a palindrome", "stressed", true},
+		{"Reversed is also a word and not is a palindrome", "hello", false},
+		{"Remains a word when the first letter is removed", "hello", true},
+		{"Is an anagram of another word", "silent", true},
+		{"Contains another word", "hello", true},
+		{"Contains another word", "stressed", false},
+		{"Contains a 4+ letter word", "stressed", false},
+		{"Contains a 4+ letter word", "xlistenx", true},
+		{"Contains a 4+ letter word", "xellox", true},
+		{"Starts with a word", "listens", true},
+		{"Starts with a word", "hello", false},
+		{"Ends with a word", "hello", true},
+		{"Hides the word CAT", "scatter", true},
+		{"Hides the word CAT", "cat", false},
+		{"Scrabble score of 9 or more", "hello", false},
+	}
+
+	for _, tt := range tests {
+		n, err := parseClue(tt.clue)
+		if err != nil {
+			t.Fatalf("parseClue(%q) returned error: %v", tt.clue, err)
+		}
+		if result := n.Compile(ctx)(tt.word); result != tt.expected {
+			t.Errorf("parseClue(%q).Compile(ctx)(%q) = %v, want %v", tt.clue, tt.word, result, tt.expected)
+		}
+	}
+}
+
+func TestCompileWithLetterValues(t *testing.T) {
+	n, err := parseClue("Scrabble score of 9 or more")
+	if err != nil {
+		t.Fatalf("parseClue returned error: %v", err)
+	}
+	ctx := newContext(nil)
+	if n.Compile(ctx)("hello") {
+		t.Errorf("hello scores 9 or more with scrabble values")
+	}
+	ctx.LetterValues = wordsWithFriendsValues
+	if !n.Compile(ctx)("hello") {
+		t.Errorf("hello does not score 9 or more with wwf values")
+	}
+}
+
+func TestParseOrdinal(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected int
+		ok       bool
+	}{
+		{"first", 1, true},
+		{"twelfth", 12, true},
+		{"1st", 1, true},
+		{"2nd", 2, true},
+		{"3rd", 3, true},
+		{"11th", 11, true},
+		{"22nd", 22, true},
+		{"2th", 0, false},
+		{"11st", 0, false},
+		{"0th", 0, false},
+		{"last", 0, false},
+	}
+
+	for _, tt := range tests {
+		n, ok := parseOrdinal(tt.s)
+		if n != tt.expected || ok != tt.ok {
+			t.Errorf("parseOrdinal(%q) = %d, %v, want %d, %v", tt.s, n, ok, tt.expected, tt.ok)
+		}
+		if m, _ := parseOrdinal(formatOrdinal(n)); ok && m != n {
+			t.Errorf("parseOrdinal(formatOrdinal(%d)) = %d, want %d", n, m, n)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		s        string
+		expected int
+		ok       bool
+	}{
+		{"5", 5, true},
+		{"12", 12, true},
+		{"five", 5, true},
+		{"twelve", 12, true},
+		{"nineteen", 19, true},
+		{"twenty", 20, true},
+		{"twenty-one", 21, true},
+		{"ninety-nine", 99, true},
+		{"twenty-zero", 0, false},
+		{"twenty-ten", 0, false},
+		{"eleventy", 0, false},
+		{"ten-one", 0, false},
+		{"5x", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		n, err := parseNumber(tt.s)
+		if n != tt.expected || (err == nil) != tt.ok {
+			t.Errorf("parseNumber(%q) = %d, %v, want %d, ok = %v", tt.s, n, err, tt.expected, tt.ok)
+		}
+	}
+
+	for n := 0; n < 100; n++ {
+		if m, err := parseNumber(numberWordOf(n)); err != nil || m != n {
+			t.Errorf("parseNumber(numberWordOf(%d)) = %d, %v, want %d", n, m, err, n)
+		}
+	}
+	if w := numberWordOf(100); w != "" {
+		t.Errorf("numberWordOf(100) = %q, want \"\"", w)
+	}
+}
+
+func TestNodeString(t *testing.T) {
+	tests := []struct {
+		node     Node
+		expected string
+	}{
+		{Not{Node: LengthRange{Min: 0, Max: 5}}, "Not 5 letters or fewer"},
+		{And{Nodes: []Node{StartsWith{Prefix: "a"}, EndsWith{Suffix: "e"}}}, "Starts with a and ends with e"},
+		{Or{Nodes: []Node{StartsWith{Prefix: "a"}, EndsWith{Suffix: "e"}}}, "Starts with a or ends with e"},
+		{
+			And{Nodes: []Node{Or{Nodes: []Node{StartsWith{Prefix: "a"}, EndsWith{Suffix: "e"}}}, DoubleLetter{}}},
+			"(starts with a or ends with e) and double letter",
+		},
+		{Not{Node: And{Nodes: []Node{DoubleLetter{}, Always{}}}}, "Not (double letter and infinity)"},
+		{CountAtLeast{Substring: "e", N: 3}, "At least 3 e's"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.node.String(); got != tt.expected {
+			t.Errorf("%#v.String() = %q, want %q", tt.node, got, tt.expected)
+		}
+	}
+}
+
+func TestRegisteredClueExamples(t *testing.T) {
+	for _, c := range registeredClues() {
+		if len(c.Examples) == 0 {
+			t.Errorf("clue %q has no examples", c.Pattern)
+		}
+		for _, example := range c.Examples {
+			n, err := parseClue(example)
+			if err != nil {
+				t.Errorf("parseClue(%q) returned error: %v", example, err)
+				continue
+			}
+			reparsed, err := parseClue(n.String())
+			if err != nil {
+				t.Errorf("parseClue(%q) returned error: %v", n.String(), err)
+				continue
+			}
+			if !reflect.DeepEqual(reparsed, n) {
+				t.Errorf("parseClue(%q) = %#v, want %#v", n.String(), reparsed, n)
+			}
+		}
+	}
+}
+
+func TestRegisterClue(t *testing.T) {
+	saved := clueRegistry
+	defer func() { clueRegistry = saved }()
+
+	RegisterClue(Clue{
+		Pattern:     "{n}-letter {text}-word",
+		Description: "The word must have N letters and contain X.",
+		Examples:    []string{"5-letter el-word"},
+		Func: func(ctx *Context, args Args) func(word string) bool {
+			length := wordLengthEqualsTo(args.Int(0))
+			contains := wordContains([]string{args.Text(1)})
+			return func(word string) bool {
+				return length(word) && contains(word)
+			}
+		},
+	})
+
+	n, err := parseClue("5-Letter el-word or starts with x")
+	if err != nil {
+		t.Fatalf("parseClue returned error: %v", err)
+	}
+	if got, want := n.String(), "5-letter el-word or starts with x"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	f := n.Compile(emptyContext)
+	for word, expected := range map[string]bool{"hello": true, "hilly": false, "xi": true, "yells": true} {
+		if result := f(word); result != expected {
+			t.Errorf("f(%q) = %v, want %v", word, result, expected)
+		}
+	}
+
+	var malformed *MalformedNumberError
+	if _, err := parseClue("x-letter el-word"); !errors.As(err, &malformed) {
+		t.Errorf("parseClue error = %v, want *MalformedNumberError", err)
+	}
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Clue describes a family of clues: how it is phrased, what it means and how
+// to build a Node from it.
+//
+// Pattern is the phrasing of the clue with placeholders for its arguments,
+// e.g. "between {n} and {n} letters". The available placeholders are listed in
+// placeholders; {list} must be a token of its own, while the others may be
+// surrounded by other characters, as in "multiple {text}'s".
+//
+// Build returns the node for the parsed arguments. Families without a
+// dedicated node type may leave Build nil and set Func instead, in which case
+// the clue parses to a ClueNode.
+type Clue struct {
+	Pattern     string
+	Description string
+	Examples    []string
+	Build       func(args Args) (Node, error)
+	Func        func(ctx *Context, args Args) func(word string) bool
+
+	tokens []patternToken
+}
+
+// Args holds the parsed arguments of a clue, in the order of the placeholders
+// in its pattern.
+type Args []any
+
+// Returns the i-th argument, which must be a {text}, {pattern}, {regexp} or {range} placeholder.
+func (a Args) Text(i int) string {
+	return a[i].(string)
+}
+
+// Returns the i-th argument, which must be a {list} placeholder.
+func (a Args) List(i int) []string {
+	return a[i].([]string)
+}
+
+// Returns the i-th argument, which must be a {n}, {nword}, {ordinal} or {position} placeholder.
+func (a Args) Int(i int) int {
+	return a[i].(int)
+}
+
+// ClueNode is the node of a registered clue family that has no dedicated node type.
+type ClueNode struct {
+	Clue *Clue
+	Args Args
+}
+
+func (n ClueNode) Compile(ctx *Context) func(word string) bool {
+	return n.Clue.Func(ctx, n.Args)
+}
+
+func (n ClueNode) String() string {
+	return capitalize(n.Clue.render(n.Args))
+}
+
+// A placeholder parses an argument from the text it matched and formats it back.
+type placeholder struct {
+	parse  func(clue, s string) (any, error)
+	format func(v any) string
+}
+
+var placeholders = map[string]placeholder{
+	// Any word.
+	"text": {
+		parse: func(clue, s string) (any, error) {
+			return s, nil
+		},
+		format: func(v any) string {
+			return v.(string)
+		},
+	},
+	// A comma-separated list of one or more words.
+	"list": {
+		format: func(v any) string {
+			return strings.Join(v.([]string), ", ")
+		},
+	},
+	// A number written in digits or spelled out, printed in digits.
+	"n": {
+		parse: func(clue, s string) (any, error) {
+			n, err := parseNumber(s)
+			if err != nil {
+				return nil, &MalformedNumberError{Clue: clue, Number: s, Err: err}
+			}
+			return n, nil
+		},
+		format: func(v any) string {
+			return strconv.Itoa(v.(int))
+		},
+	},
+	// A letter position counted from 1, written like {n}.
+	"position": {
+		parse: func(clue, s string) (any, error) {
+			n, err := parseNumber(s)
+			if err != nil {
+				return nil, &MalformedNumberError{Clue: clue, Number: s, Err: err}
+			}
+			if n < 1 {
+				return nil, &InvalidPositionError{Clue: clue, Position: n}
+			}
+			return n, nil
+		},
+		format: func(v any) string {
+			return strconv.Itoa(v.(int))
+		},
+	},
+	// An ordinal such as "second" or "2nd".
+	"ordinal": {
+		parse: func(clue, s string) (any, error) {
+			n, ok := parseOrdinal(s)
+			if !ok {
+				return nil, &UnknownNumberWordError{Clue: clue, Word: s}
+			}
+			return n, nil
+		},
+		format: func(v any) string {
+			return formatOrdinal(v.(int))
+		},
+	},
+	// A range of letters such as "a-m", parsed to the letters it contains.
+	"range": {
+		parse: func(clue, s string) (any, error) {
+			if len(s) != 3 || s[1] != '-' || s[0] < 'a' || s[2] > 'z' || s[0] > s[2] {
+				return nil, &UnknownClueError{Clue: clue}
+			}
+			return letterRange(s[0], s[2]), nil
+		},
+		format: func(v any) string {
+			letters := v.(string)
+			return letters[:1] + "-" + letters[len(letters)-1:]
+		},
+	},
+	// A crossword-style pattern, see crosswordToRegexp.
+	"pattern": {
+		parse: func(clue, s string) (any, error) {
+			if _, err := crosswordToRegexp(s); err != nil {
+				return nil, &InvalidPatternError{Clue: clue, Pattern: s, Err: err}
+			}
+			return s, nil
+		},
+		format: func(v any) string {
+			return v.(string)
+		},
+	},
+	// A regular expression between slashes.
+	"regexp": {
+		parse: func(clue, s string) (any, error) {
+			if _, err := regexp.Compile(s); err != nil {
+				return nil, &InvalidPatternError{Clue: clue, Pattern: s, Err: err}
+			}
+			return s, nil
+		},
+		format: func(v any) string {
+			return "/" + strings.ReplaceAll(v.(string), "/", `\/`) + "/"
+		},
+	},
+	// A number written in digits or spelled out, printed spelled out.
+	"nword": {
+		parse: func(clue, s string) (any, error) {
+			n, err := parseNumber(s)
+			if err != nil {
+				return nil, &UnknownNumberWordError{Clue: clue, Word: s}
+			}
+			return n, nil
+		},
+		format: func(v any) string {
+			if w := numberWordOf(v.(int)); w != "" {
+				return w
+			}
+			return strconv.Itoa(v.(int))
+		},
+	},
+}
+
+type patternToken struct {
+	// literal is the text the token must match, if it has no placeholders.
+	literal string
+	// template is the text of a token with placeholders, e.g. "{text}'s".
+	template string
+	// names are the placeholders in template, matched by re.
+	names []string
+	re    *regexp.Regexp
+}
+
+var placeholderRe = regexp.MustCompile(`\{(\w+)\}`)
+
+func compilePattern(pattern string) ([]patternToken, error) {
+	var tokens []patternToken
+	for _, t := range tokenize(pattern) {
+		matches := placeholderRe.FindAllStringSubmatchIndex(t.text, -1)
+		if matches == nil {
+			tokens = append(tokens, patternToken{literal: t.text})
+			continue
+		}
+
+		var names []string
+		var expr strings.Builder
+		expr.WriteString("^")
+		last := 0
+		for _, m := range matches {
+			name := t.text[m[2]:m[3]]
+			if _, ok := placeholders[name]; !ok {
+				return nil, fmt.Errorf("unknown placeholder {%s} in pattern %q", name, pattern)
+			}
+			if (name == "list" || name == "regexp") && t.text != "{"+name+"}" {
+				return nil, fmt.Errorf("{%s} must be a separate token in pattern %q", name, pattern)
+			}
+			names = append(names, name)
+			expr.WriteString(regexp.QuoteMeta(t.text[last:m[0]]))
+			expr.WriteString("(.+?)")
+			last = m[1]
+		}
+		expr.WriteString(regexp.QuoteMeta(t.text[last:]))
+		expr.WriteString("$")
+		tokens = append(tokens, patternToken{template: t.text, names: names, re: regexp.MustCompile(expr.String())})
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty pattern")
+	}
+	return tokens, nil
+}
+
+var clueRegistry []*Clue
+
+// RegisterClue adds a clue family to the parser. It panics if the pattern is
+// invalid or the clue has neither Build nor Func, so it is meant to be called
+// from init functions.
+func RegisterClue(c Clue) {
+	tokens, err := compilePattern(c.Pattern)
+	if err != nil {
+		panic(err)
+	}
+	if c.Build == nil && c.Func == nil {
+		panic(fmt.Sprintf("clue %q has neither Build nor Func", c.Pattern))
+	}
+	c.tokens = tokens
+	clueRegistry = append(clueRegistry, &c)
+}
+
+// Returns the registered clue families in registration order.
+func registeredClues() []*Clue {
+	return clueRegistry
+}
+
+// Matches the clue's pattern against the parser's tokens starting at the
+// current position. Returns the position after the match and the raw
+// arguments, or ok = false if the tokens do not fit the pattern.
+func (c *Clue) match(p *parser) (end int, raw []any, ok bool) {
+	pos := p.pos
+	for _, pt := range c.tokens {
+		if pos >= len(p.tokens) {
+			return 0, nil, false
+		}
+		t := p.tokens[pos]
+
+		switch {
+		case pt.re == nil:
+			if t.kind == tokenRegexp || t.text != pt.literal {
+				return 0, nil, false
+			}
+			pos++
+		case pt.names[0] == "list":
+			var items []string
+			for pos < len(p.tokens) && p.tokens[pos].kind == tokenWord {
+				items = append(items, p.tokens[pos].text)
+				pos++
+				if pos+1 < len(p.tokens) && p.tokens[pos].text == "," && p.tokens[pos+1].kind == tokenWord {
+					pos++
+					continue
+				}
+				break
+			}
+			if items == nil {
+				return 0, nil, false
+			}
+			raw = append(raw, items)
+		case pt.names[0] == "regexp":
+			if t.kind != tokenRegexp {
+				return 0, nil, false
+			}
+			raw = append(raw, t.text)
+			pos++
+		default:
+			if t.kind != tokenWord {
+				return 0, nil, false
+			}
+			m := pt.re.FindStringSubmatch(t.text)
+			if m == nil {
+				return 0, nil, false
+			}
+			for _, s := range m[1:] {
+				raw = append(raw, s)
+			}
+			pos++
+		}
+	}
+	return pos, raw, true
+}
+
+// Converts raw arguments matched by the clue's pattern into Args.
+func (c *Clue) parseArgs(clue string, raw []any) (Args, error) {
+	var names []string
+	for _, pt := range c.tokens {
+		names = append(names, pt.names...)
+	}
+
+	args := make(Args, len(raw))
+	for i, name := range names {
+		if name == "list" {
+			args[i] = raw[i]
+			continue
+		}
+		v, err := placeholders[name].parse(clue, raw[i].(string))
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	return args, nil
+}
+
+// Builds the node for the parsed arguments.
+func (c *Clue) build(args Args) (Node, error) {
+	if c.Build != nil {
+		return c.Build(args)
+	}
+	return ClueNode{Clue: c, Args: args}, nil
+}
+
+// Prints the pattern with its placeholders replaced by args.
+func (c *Clue) render(args Args) string {
+	var words []string
+	i := 0
+	for _, pt := range c.tokens {
+		if pt.re == nil {
+			words = append(words, pt.literal)
+			continue
+		}
+		j := 0
+		word := placeholderRe.ReplaceAllStringFunc(pt.template, func(string) string {
+			s := placeholders[pt.names[j]].format(args[i])
+			i++
+			j++
+			return s
+		})
+		words = append(words, word)
+	}
+	return strings.ReplaceAll(strings.Join(words, " "), " ,", ",")
+}
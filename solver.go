@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Number of shards the dictionary is split into for each worker, so that
+// workers that finish early can pick up the remaining shards.
+const shardsPerWorker = 4
+
+// Solves every cell of the grid on up to workers goroutines. The results are
+// the same, in the same order, for any number of workers: columns in order,
+// and for each column the rows in order.
+func solve(ctx *Context, row_predicates, col_predicates []Predicate, workers int) []Result {
+	rowMatches := selectPredicates(ctx, row_predicates, workers)
+	colMatches := selectPredicates(ctx, col_predicates, workers)
+
+	results := make([]Result, len(col_predicates)*len(row_predicates))
+	parallelFor(len(results), workers, func(cell int) {
+		i, j := cell/len(row_predicates), cell%len(row_predicates)
+		col, row := col_predicates[i], row_predicates[j]
+		result := Result{
+			Name:       fmt.Sprintf("%s & %s", col.Name, row.Name),
+			Condition1: col.Name,
+			Condition2: row.Name,
+		}
+		if col.Err != nil || row.Err != nil {
+			result.Status = StatusUnsupported
+			result.Words = []Word{}
+		} else {
+			result.Status = StatusOK
+			result.Words = ctx.taggedWordsIn(colMatches[i].And(rowMatches[j]))
+		}
+		results[cell] = result
+	})
+	return results
+}
+
+// Returns the words each predicate matches, or nil for predicates that could
+// not be parsed.
+func selectPredicates(ctx *Context, predicates []Predicate, workers int) []Bitset {
+	matches := make([]Bitset, len(predicates))
+	for i, p := range predicates {
+		if p.Err == nil {
+			matches[i] = ctx.SelectParallel(p.Node, workers)
+		}
+	}
+	return matches
+}
+
+// Calls f for each i from 0 to n-1 on up to workers goroutines and waits for
+// the calls to return. With a single worker, f is called in order on the
+// calling goroutine.
+func parallelFor(n, workers int, f func(i int)) {
+	if workers <= 1 || n <= 1 {
+		for i := range n {
+			f(i)
+		}
+		return
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				f(i)
+			}
+		}()
+	}
+	for i := range n {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func newTestPredicates(clues ...string) []Predicate {
+	predicates := make([]Predicate, len(clues))
+	for i, clue := range clues {
+		predicates[i] = newPredicate(clue, "")
+	}
+	return predicates
+}
+
+// Returns the rows and columns of a grid with one unsupported column.
+func newTestGrid() (rows, cols []Predicate) {
+	rows = newTestPredicates("Starts with s", "Double letter", "Contains a 4+ letter word")
+	cols = newTestPredicates("Five letter word", "Ends with ing", "Rhymes with orange")
+	return rows, cols
+}
+
+func TestSolveMatchesSequential(t *testing.T) {
+	words := make([]string, 0, 1000)
+	for _, w := range []string{"sweet", "string", "sing", "starting", "seeing", "apple", "bookkeeping", "sells", "stressed"} {
+		for _, suffix := range []string{"", "s", "ing", "er", "ly"} {
+			words = append(words, w+suffix)
+		}
+	}
+	ctx := newContext(words)
+	rows, cols := newTestGrid()
+
+	want := solve(ctx, rows, cols, 1)
+	for _, workers := range []int{2, 3, 8, 64} {
+		if got := solve(ctx, rows, cols, workers); !reflect.DeepEqual(got, want) {
+			t.Errorf("solve() with %d workers = %+v, want %+v", workers, got, want)
+		}
+	}
+
+	if want[6].Status != StatusUnsupported || want[0].Status != StatusOK {
+		t.Errorf("solve() statuses = %q, %q, want %q, %q", want[0].Status, want[6].Status, StatusOK, StatusUnsupported)
+	}
+	var cell []string
+	for _, w := range want[0].Words {
+		cell = append(cell, w.Word)
+	}
+	if got, want := cell, []string{"sweet", "sings", "sells"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("solve()[0].Words = %q, want %q", got, want)
+	}
+}
+
+func benchmarkSolve(b *testing.B, workers int) {
+	ctx := newContext(splitWords(embeddedWords))
+	rows, cols := newTestGrid()
+	b.ResetTimer()
+	for range b.N {
+		solve(ctx, rows, cols, workers)
+	}
+}
+
+func BenchmarkSolveSequential(b *testing.B) {
+	benchmarkSolve(b, 1)
+}
+
+func BenchmarkSolveParallel(b *testing.B) {
+	benchmarkSolve(b, runtime.GOMAXPROCS(0))
+}
@@ -0,0 +1,108 @@
+package main
+
+import "iter"
+
+// Trie indexes words by their letters, so that the words with a given prefix
+// can be listed without scanning the whole dictionary. Words are identified
+// by their id in a Dictionary.
+//
+// Nodes are kept in a single slice, and the children of a node form a linked
+// list in ascending order of their letters.
+type Trie struct {
+	nodes []trieNode
+}
+
+type trieNode struct {
+	letter rune
+	// id is the id of the word ending at this node, or -1 if there is none.
+	id int32
+	// Indexes in nodes of the first and last child and of the next sibling,
+	// or 0 if there is none. The root is never a child or a sibling.
+	firstChild, lastChild, next int32
+}
+
+func newTrie() *Trie {
+	return &Trie{nodes: []trieNode{{id: -1}}}
+}
+
+// Adds the word spelled by letters with the given id. Inserting words in
+// alphabetical order is fastest.
+func (t *Trie) Insert(letters []rune, id int) {
+	node := int32(0)
+	for _, r := range letters {
+		node = t.child(node, r)
+	}
+	t.nodes[node].id = int32(id)
+}
+
+// Returns the child of node for letter r, adding it if there is none.
+func (t *Trie) child(node int32, r rune) int32 {
+	parent := &t.nodes[node]
+	if last := parent.lastChild; last != 0 && t.nodes[last].letter <= r {
+		if t.nodes[last].letter == r {
+			return last
+		}
+		return t.addChild(node, last, 0, r)
+	}
+
+	prev := int32(0)
+	for c := parent.firstChild; c != 0; c = t.nodes[c].next {
+		switch {
+		case t.nodes[c].letter == r:
+			return c
+		case t.nodes[c].letter > r:
+			return t.addChild(node, prev, c, r)
+		}
+		prev = c
+	}
+	return t.addChild(node, prev, 0, r)
+}
+
+// Adds a child of node for letter r between the siblings prev and next,
+// either of which may be 0.
+func (t *Trie) addChild(node, prev, next int32, r rune) int32 {
+	c := int32(len(t.nodes))
+	t.nodes = append(t.nodes, trieNode{letter: r, id: -1, next: next})
+	if prev == 0 {
+		t.nodes[node].firstChild = c
+	} else {
+		t.nodes[prev].next = c
+	}
+	if next == 0 {
+		t.nodes[node].lastChild = c
+	}
+	return c
+}
+
+// Returns the ids of the words beginning with the letters of prefix, in
+// alphabetical order of the words.
+func (t *Trie) WithPrefix(prefix []rune) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		node := int32(0)
+		for _, r := range prefix {
+			c := t.nodes[node].firstChild
+			for c != 0 && t.nodes[c].letter != r {
+				c = t.nodes[c].next
+			}
+			if c == 0 {
+				return
+			}
+			node = c
+		}
+		t.walk(node, yield)
+	}
+}
+
+// Calls yield with the id of every word at or below node until yield returns
+// false. Reports whether the walk completed.
+func (t *Trie) walk(node int32, yield func(int) bool) bool {
+	if id := t.nodes[node].id; id >= 0 && !yield(int(id)) {
+		return false
+	}
+	for c := t.nodes[node].firstChild; c != 0; c = t.nodes[c].next {
+		if !t.walk(c, yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestTrieWithPrefix(t *testing.T) {
+	words := []string{"mint", "mi", "mine", "mango", "café", "cafés", ""}
+	trie := newTrie()
+	for id, w := range words {
+		trie.Insert([]rune(w), id)
+	}
+
+	tests := []struct {
+		prefix   string
+		expected []int
+	}{
+		{"mi", []int{1, 2, 0}},
+		{"min", []int{2, 0}},
+		{"m", []int{3, 1, 2, 0}},
+		{"caf", []int{4, 5}},
+		{"café", []int{4, 5}},
+		{"mx", nil},
+		{"", []int{6, 4, 5, 3, 1, 2, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			if got := slices.Collect(trie.WithPrefix([]rune(tt.prefix))); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("WithPrefix(%q) = %v, want %v", tt.prefix, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDictionaryWordsWithAffix(t *testing.T) {
+	d := newDictionary([]string{"singing", "sing", "ring", "rings", "string", "song"})
+
+	if got, want := slices.Collect(d.WordsWithPrefix("sin")), []string{"sing", "singing"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WordsWithPrefix(%q) = %q, want %q", "sin", got, want)
+	}
+	if got, want := slices.Collect(d.WordsWithSuffix("ing")), []string{"singing", "ring", "string", "sing"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WordsWithSuffix(%q) = %q, want %q", "ing", got, want)
+	}
+
+	var first []string
+	for w := range d.WordsWithSuffix("ng") {
+		first = append(first, w)
+		if len(first) == 2 {
+			break
+		}
+	}
+	if want := []string{"singing", "ring"}; !reflect.DeepEqual(first, want) {
+		t.Errorf("first words of WordsWithSuffix(%q) = %q, want %q", "ng", first, want)
+	}
+}
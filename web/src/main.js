@@ -1,5 +1,37 @@
 import results from './results.json'
 
+// Names of the word lists the words were checked against.
+const lists = results.lists ?? [];
+
+// Words are plain strings unless they have list tags or a rarity. Words
+// without lists are in every list.
+results.results.forEach(result => {
+    result.words = (result.words ?? []).map(word => typeof word === "string" ? { word } : word);
+});
+
+// Whether words come with a rarity, from 0 for common words to 1 for the rarest.
+const hasRarity = results.results.some(result => result.words.some(word => word.rarity !== undefined));
+
+// The list to show words from, or "" for words in any list.
+let selectedList = "";
+
+function visibleWords(result) {
+    if (selectedList === "") {
+        return result.words;
+    }
+    return result.words.filter(word => word.lists === undefined || word.lists.includes(selectedList));
+}
+
+function listTag(word) {
+    if (lists.length < 2) {
+        return "";
+    }
+    if (word.lists === undefined) {
+        return "in all lists";
+    }
+    return `${word.lists.join(", ")} only`;
+}
+
 function createRow(container, results) {
     const rowHeader = document.createElement("div");
     rowHeader.textContent = results[0].condition_1;
@@ -9,7 +41,16 @@ function createRow(container, results) {
     results.forEach(result => {
         const button = document.createElement("button");
         button.className = "outline contrast";
-        button.textContent = `${result.words.length} words`;
+
+        if (result.status === "unsupported") {
+            button.textContent = "Unsupported";
+            button.disabled = true;
+            container.appendChild(button);
+            return;
+        }
+
+        const words = visibleWords(result);
+        button.textContent = `${words.length} words`;
 
         button.addEventListener("click", () => {
             const modal = document.getElementById("modal");
@@ -20,9 +61,19 @@ function createRow(container, results) {
 
             const modalList = document.getElementById("modal-list");
             modalList.innerHTML = "";
-            result.words.forEach(word => {
+            words.forEach(word => {
                 const listItem = document.createElement("li");
-                listItem.textContent = word;
+                listItem.dataset.word = word.word;
+                listItem.dataset.rarity = word.rarity ?? 0;
+                listItem.textContent = word.word;
+
+                const tag = listTag(word);
+                if (tag !== "") {
+                    const small = document.createElement("small");
+                    small.className = "list-tag";
+                    small.textContent = tag;
+                    listItem.appendChild(small);
+                }
                 modalList.appendChild(listItem);
             });
         });
@@ -43,22 +94,45 @@ function createColumnHeaders(container, results) {
     });
 }
 
-let container = document.getElementById("results");
+function render() {
+    let container = document.getElementById("results");
+    container.innerHTML = "";
+
+    createColumnHeaders(container, results.results.slice(0, 3));
+    createRow(container, results.results.slice(0, 3));
+    createRow(container, results.results.slice(3, 6));
+    createRow(container, results.results.slice(6, 9));
+}
+
 let gameInfo = document.getElementById("game-info");
 
 gameInfo.textContent = `Game #${results.game_number} - ${new Date(results.timestamp).toLocaleDateString()}`;
 
-createColumnHeaders(container, results.results.slice(0, 3));
-createRow(container, results.results.slice(0, 3));
-createRow(container, results.results.slice(3, 6));
-createRow(container, results.results.slice(6, 9));
+// Let users pick the word list closest to the official answers
+if (lists.length > 1) {
+    const listPicker = document.getElementById("list-picker");
+    const listSelect = document.getElementById("list-select");
+    lists.forEach(list => {
+        const option = document.createElement("option");
+        option.value = list;
+        option.textContent = list;
+        listSelect.appendChild(option);
+    });
+    listSelect.addEventListener("change", () => {
+        selectedList = listSelect.value;
+        render();
+    });
+    listPicker.hidden = false;
+}
+
+render();
 
 // Sort alphabetically
 const buttonSort1 = document.getElementById("modal-sort-1");
 buttonSort1.addEventListener("click", () => {
     const modalList = document.getElementById("modal-list");
     const items = Array.from(modalList.children);
-    items.sort((a, b) => a.textContent.localeCompare(b.textContent));
+    items.sort((a, b) => a.dataset.word.localeCompare(b.dataset.word));
     modalList.innerHTML = "";
     items.forEach(item => modalList.appendChild(item));
 });
@@ -68,7 +142,18 @@ const buttonSort2 = document.getElementById("modal-sort-2");
 buttonSort2.addEventListener("click", () => {
     const modalList = document.getElementById("modal-list");
     const items = Array.from(modalList.children);
-    items.sort((a, b) => b.textContent.length - a.textContent.length);
+    items.sort((a, b) => b.dataset.word.length - a.dataset.word.length);
+    modalList.innerHTML = "";
+    items.forEach(item => modalList.appendChild(item));
+});
+
+// Sort by rarity, rarest first
+const buttonSort3 = document.getElementById("modal-sort-3");
+buttonSort3.hidden = !hasRarity;
+buttonSort3.addEventListener("click", () => {
+    const modalList = document.getElementById("modal-list");
+    const items = Array.from(modalList.children);
+    items.sort((a, b) => b.dataset.rarity - a.dataset.rarity);
     modalList.innerHTML = "";
     items.forEach(item => modalList.appendChild(item));
 });
@@ -77,4 +162,4 @@ const modalCloseBtn = document.getElementById("modal-close");
 modalCloseBtn.addEventListener("click", () => {
     const modal = document.getElementById("modal");
     modal.removeAttribute("open");
-});
\ No newline at end of file
+});